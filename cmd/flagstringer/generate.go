@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"math/bits"
+	"slices"
+)
+
+// value is a constant of the flag type.
+type value struct {
+	name string
+	bits uint64
+	pos  token.Pos
+}
+
+// generate type-checks `files` and returns the source of a file with a name table and `String()` method for `typeName`.
+//
+// `command` is recorded in the "Code generated" header.
+func generate(fset *token.FileSet, files []*ast.File, typeName, command string) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files to read")
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, info)
+	if err != nil {
+		return nil, err
+	}
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s not found in package %s", typeName, pkg.Name())
+	}
+	typ, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", typeName)
+	}
+	if basic, ok := typ.Underlying().(*types.Basic); !ok || basic.Info()&types.IsUnsigned == 0 {
+		return nil, fmt.Errorf("%s must have an unsigned integer underlying type, got %s", typeName, typ.Underlying())
+	}
+
+	var values []value
+	for ident, obj := range info.Defs {
+		c, ok := obj.(*types.Const)
+		if !ok || c.Parent() != pkg.Scope() || !types.Identical(c.Type(), typ) || ident.Name == "_" {
+			continue
+		}
+		v, _ := constant.Uint64Val(c.Val())
+		values = append(values, value{name: ident.Name, bits: v, pos: ident.Pos()})
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no constants of type %s found", typeName)
+	}
+	// more bits first so combinations like All win over their parts, then in source order
+	slices.SortFunc(values, func(a, b value) int {
+		return cmp.Or(
+			cmp.Compare(bits.OnesCount64(b.bits), bits.OnesCount64(a.bits)),
+			cmp.Compare(a.pos, b.pos),
+		)
+	})
+
+	var buf bytes.Buffer
+	tableName := "_" + typeName + "_names"
+	fmt.Fprintf(&buf, "// Code generated by \"%s\"; DO NOT EDIT.\n\n", command)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(&buf, "import (\n\t\"strconv\"\n\t\"strings\"\n)\n\n")
+	fmt.Fprintf(&buf, "// %s maps each %s constant to its name, with multi-bit constants first.\n", tableName, typeName)
+	fmt.Fprintf(&buf, "var %s = []struct {\n\tflag %s\n\tname string\n}{\n", tableName, typeName)
+	for _, v := range values {
+		fmt.Fprintf(&buf, "\t{%s, %q},\n", v.name, v.name)
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+	fmt.Fprintf(&buf, `// String returns the names of the flags set in b joined by "|", with any unnamed bits as a trailing hex term.
+func (b %[1]s) String() string {
+	var names []string
+	for _, e := range %[2]s {
+		if e.flag == b || e.flag != 0 && b&e.flag == e.flag {
+			names = append(names, e.name)
+			b &^= e.flag
+			if b == 0 {
+				break
+			}
+		}
+	}
+	if b != 0 || len(names) == 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(b), 16))
+	}
+	return strings.Join(names, "|")
+}
+`, typeName, tableName)
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func parseFiles(t *testing.T, fset *token.FileSet, paths ...string) []*ast.File {
+	t.Helper()
+	var files []*ast.File
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func TestGenerateGolden(t *testing.T) {
+	fset := token.NewFileSet()
+	files := parseFiles(t, fset, filepath.Join("testdata", "perm.go"))
+	got, err := generate(fset, files, "Perm", "flagstringer -type=Perm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "perm_flag.go.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("generated source does not match %s (run go test -update to rewrite it):\n%s", golden, got)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	fset := token.NewFileSet()
+	files := parseFiles(t, fset, filepath.Join("testdata", "perm.go"))
+	for _, typeName := range []string{"Missing", "Other"} {
+		if _, err := generate(fset, files, typeName, "flagstringer"); err == nil {
+			t.Fatalf("generate(%q) did not return an error", typeName)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+# Flagstringer generates `String()` methods for bit flag types.
+
+It works like the standard `stringer` tool, but understands bit flags: a value with several flags set
+is rendered as their names joined by "|", e.g. "Read|Write".
+
+Given a package with
+
+	type Perm uint32
+
+	const (
+		Read Perm = 1 << iota
+		Write
+		Execute
+		All = Read | Write | Execute
+	)
+
+running `flagstringer -type=Perm` in that package's directory writes `perm_flag.go`, containing a name table of
+the `Perm` constants and a `String()` method that uses it. Constants with more bits set are matched first, so
+`(Read|Write|Execute).String()` is "All". Bits with no name are added as a trailing hex term, e.g. "Read|0x100".
+
+It is meant to be run by `go generate`:
+
+	//go:generate go run github.com/chasecarlson1/go-bitflags/cmd/flagstringer -type=Perm
+
+# Usage:
+
+	flagstringer -type=T [-output=file] [directory]
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("flagstringer: ")
+	typeName := flag.String("type", "", "name of the flag type to generate a String method for (required)")
+	output := flag.String("output", "", "output file name (default <dir>/<type>_flag.go)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: flagstringer -type=T [-output=file] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeName == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(*typeName)+"_flag.go")
+	}
+
+	fset := token.NewFileSet()
+	files, err := parsePackage(fset, dir, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	command := "flagstringer " + strings.Join(os.Args[1:], " ")
+	src, err := generate(fset, files, *typeName, command)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parsePackage parses the non-test Go files of the package in `dir`, skipping a previously generated `output` file.
+func parsePackage(fset *token.FileSet, dir, output string) ([]*ast.File, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range pkg.GoFiles {
+		path := filepath.Join(dir, name)
+		if filepath.Clean(path) == filepath.Clean(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,26 @@
+package perm
+
+type Perm uint32
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Execute
+	_
+	Admin
+)
+
+const (
+	None      Perm = 0
+	ReadWrite      = Read | Write
+	All            = Read | Write | Execute | Admin
+)
+
+// not a Perm, so not in the name table
+const Other = 1 << 5
+
+// function-local constants are out of scope for the generated file, so not in the name table either
+func defaultPerm() Perm {
+	const local Perm = 1 << 6
+	return local
+}
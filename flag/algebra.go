@@ -0,0 +1,110 @@
+package flag
+
+// # Union returns a new Flag with the bits of both `b` and `other` set (OR)
+//
+// does not modify `b`, so calls can be chained: `a.Union(b).Difference(c)`.
+func (b Flag) Union(other Flag) Flag {
+	return b | other
+}
+
+// # Intersection returns a new Flag with only the bits set in both `b` and `other` (AND)
+func (b Flag) Intersection(other Flag) Flag {
+	return b & other
+}
+
+// # Difference returns a new Flag with the bits of `b` that are not set in `other` (AND NOT)
+func (b Flag) Difference(other Flag) Flag {
+	return b &^ other
+}
+
+// # SymmetricDifference returns a new Flag with the bits set in exactly one of `b` and `other` (XOR)
+func (b Flag) SymmetricDifference(other Flag) Flag {
+	return b ^ other
+}
+
+// # Complement returns a new Flag with every bit of `b` inverted (NOT)
+//
+// Non-mutating version of `ToggleAll()`, handy for building masks like `allowed.Complement()`.
+func (b Flag) Complement() Flag {
+	return ^b
+}
+
+// # Diff reports which bits changed going from `b` to `other`
+//
+// `added` has the bits set in `other` but not in `b`, and `removed` has the bits set in `b` but not in `other`.
+func (b Flag) Diff(other Flag) (added, removed Flag) {
+	return other &^ b, b &^ other
+}
+
+// # Or returns the OR of all the provided flags
+//
+// returns zero (the identity of OR) if no flags are provided.
+func Or(flags ...Flag) Flag {
+	var flag Flag
+	for _, f := range flags {
+		flag |= f
+	}
+	return flag
+}
+
+// # Merge combines the flags of several records into one aggregate with every bit any of them has set
+//
+// same as `Or`; returns zero if no flags are provided.
+func Merge(flags ...Flag) Flag {
+	return Or(flags...)
+}
+
+// # And returns the AND of all the provided flags
+//
+// returns all ones (`0xFFFFFFFF`, the identity of AND) if no flags are provided.
+func And(flags ...Flag) Flag {
+	var flag Flag = 0xFFFFFFFF
+	for _, f := range flags {
+		flag &= f
+	}
+	return flag
+}
+
+// # Xor returns the XOR of all the provided flags
+//
+// returns zero (the identity of XOR) if no flags are provided.
+func Xor(flags ...Flag) Flag {
+	var flag Flag
+	for _, f := range flags {
+		flag ^= f
+	}
+	return flag
+}
+
+// # HammingDistance returns the number of bit positions where `b` and `other` differ
+func (b Flag) HammingDistance(other Flag) int {
+	return (b ^ other).Count()
+}
+
+// # CommonBits returns the bits `b` and `other` share
+//
+// same result as `Intersection(other)`; reads better where the question is "what do these two have in common".
+func (b Flag) CommonBits(other Flag) Flag {
+	return b.Intersection(other)
+}
+
+// # ComplementWithin returns the bits of `universe` that are not set in `b`
+//
+// like `Complement`, but only flips bits inside `universe`, so the result never has bits outside the flags you support.
+func (b Flag) ComplementWithin(universe Flag) Flag {
+	return ^b & universe
+}
+
+// # Min returns whichever of `a` and `b` has the smaller raw `uint32` value
+//
+// the comparison is numeric, the same ordering as `Compare`, not by how many bits are set: `Min(0b100, 0b011)` is 0b011.
+func Min(a, b Flag) Flag {
+	return min(a, b)
+}
+
+// # Max returns whichever of `a` and `b` has the larger raw `uint32` value
+//
+// the comparison is numeric, the same ordering as `Compare`, not by how many bits are set: `Max(0b100, 0b011)` is 0b100.
+func Max(a, b Flag) Flag {
+	return max(a, b)
+}
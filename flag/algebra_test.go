@@ -0,0 +1,158 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestAlgebra(t *testing.T) {
+	var a, b, c flag.Flag = 0b0011, 0b0110, 0b0100
+	t.Run("Union()", func(t *testing.T) {
+		if got := a.Union(b); got != 0b0111 {
+			t.Fatalf("a.Union(b) = %#b, want 0b111", uint32(got))
+		}
+	})
+	t.Run("Intersection()", func(t *testing.T) {
+		if got := a.Intersection(b); got != 0b0010 {
+			t.Fatalf("a.Intersection(b) = %#b, want 0b10", uint32(got))
+		}
+	})
+	t.Run("Difference()", func(t *testing.T) {
+		if got := a.Difference(b); got != 0b0001 {
+			t.Fatalf("a.Difference(b) = %#b, want 0b1", uint32(got))
+		}
+	})
+	t.Run("SymmetricDifference()", func(t *testing.T) {
+		if got := a.SymmetricDifference(b); got != 0b0101 {
+			t.Fatalf("a.SymmetricDifference(b) = %#b, want 0b101", uint32(got))
+		}
+	})
+	t.Run("Complement()", func(t *testing.T) {
+		if got := a.Complement(); got != 0xFFFFFFFC {
+			t.Fatalf("a.Complement() = %#x, want 0xfffffffc", uint32(got))
+		}
+		if got := a.Complement().Complement(); got != a {
+			t.Fatalf("a.Complement().Complement() = %#b, want %#b", uint32(got), uint32(a))
+		}
+		if a != 0b0011 {
+			t.Fatal("receiver was modified")
+		}
+	})
+	t.Run("chaining", func(t *testing.T) {
+		if got := a.Union(b).Difference(c).SymmetricDifference(0b1000).Intersection(0b1010); got != 0b1010 {
+			t.Fatalf("a.Union(b).Difference(c).SymmetricDifference(0b1000).Intersection(0b1010) = %#b, want 0b1010", uint32(got))
+		}
+		if a != 0b0011 || b != 0b0110 || c != 0b0100 {
+			t.Fatal("receivers were modified")
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	for _, c := range []struct {
+		from, to       flag.Flag
+		added, removed flag.Flag
+	}{
+		{0b0001, 0b0111, 0b0110, 0},
+		{0b0111, 0b0001, 0, 0b0110},
+		{0b0011, 0b0110, 0b0100, 0b0001},
+		{0b0101, 0b0101, 0, 0},
+	} {
+		if added, removed := c.from.Diff(c.to); added != c.added || removed != c.removed {
+			t.Fatalf("Flag(%#b).Diff(%#b) = %#b, %#b, want %#b, %#b", uint32(c.from), uint32(c.to), uint32(added), uint32(removed), uint32(c.added), uint32(c.removed))
+		}
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	for _, c := range []struct {
+		flags        []flag.Flag
+		or, and, xor flag.Flag
+	}{
+		{nil, 0, 0xFFFFFFFF, 0},
+		{[]flag.Flag{0b0110}, 0b0110, 0b0110, 0b0110},
+		{[]flag.Flag{0b0011, 0b0110, 0b0010}, 0b0111, 0b0010, 0b0111},
+		{[]flag.Flag{0b0001, 0b0001}, 0b0001, 0b0001, 0},
+	} {
+		if got := flag.Or(c.flags...); got != c.or {
+			t.Fatalf("Or(%v) = %#b, want %#b", c.flags, uint32(got), uint32(c.or))
+		}
+		if got := flag.And(c.flags...); got != c.and {
+			t.Fatalf("And(%v) = %#b, want %#b", c.flags, uint32(got), uint32(c.and))
+		}
+		if got := flag.Xor(c.flags...); got != c.xor {
+			t.Fatalf("Xor(%v) = %#b, want %#b", c.flags, uint32(got), uint32(c.xor))
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	if got := flag.Merge(); got != 0 {
+		t.Fatalf("Merge() = %#b, want 0", uint32(got))
+	}
+	if got := flag.Merge(0b0011, 0b0110, 1<<31, 0b0010); got != 1<<31|0b0111 {
+		t.Fatalf("Merge() = %#x, want 0x80000007", uint32(got))
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	var f flag.Flag = 0xDEADBEEF
+	for other, want := range map[flag.Flag]int{
+		f:               0,
+		f ^ 1<<7:        1,
+		f.Complement():  32,
+		f ^ 0b1011_0000: 3,
+	} {
+		if got := f.HammingDistance(other); got != want {
+			t.Fatalf("HammingDistance(%#x) = %d, want %d", uint32(other), got, want)
+		}
+	}
+}
+
+func TestCommonBits(t *testing.T) {
+	var a, b flag.Flag = 0b1110, 0b0111
+	if got := a.CommonBits(b); got != a&b {
+		t.Fatalf("CommonBits(%#b) = %#b, want %#b", uint32(b), uint32(got), uint32(a&b))
+	}
+	if a != 0b1110 || b != 0b0111 {
+		t.Fatalf("CommonBits() mutated its operands: %#b, %#b", uint32(a), uint32(b))
+	}
+}
+
+func TestComplementWithin(t *testing.T) {
+	const universe flag.Flag = 0b0111
+	for f, want := range map[flag.Flag]flag.Flag{
+		0:          0b0111,
+		0b0101:     0b0010,
+		0b0111:     0,
+		0b1101:     0b0010, // bit 3 is outside the universe
+		0xFFFFFFF0: 0b0111,
+	} {
+		got := f.ComplementWithin(universe)
+		if got != want || got&^universe != 0 {
+			t.Fatalf("ComplementWithin(%#b) of %#x = %#b, want %#b", uint32(universe), uint32(f), uint32(got), uint32(want))
+		}
+		if sub := f & universe; sub.ComplementWithin(universe).ComplementWithin(universe) != sub {
+			t.Fatalf("double ComplementWithin() of %#b is not the identity", uint32(sub))
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	for _, c := range []struct {
+		a, b, min, max flag.Flag
+	}{
+		{0b101, 0b101, 0b101, 0b101},
+		{0b100, 0b011, 0b011, 0b100}, // fewer bits set but a larger value
+		{0b011, 0b100, 0b011, 0b100},
+		{1 << 31, 0x7FFFFFFF, 0x7FFFFFFF, 1 << 31},
+	} {
+		if got := flag.Min(c.a, c.b); got != c.min {
+			t.Fatalf("Min(%#x, %#x) = %#x, want %#x", uint32(c.a), uint32(c.b), uint32(got), uint32(c.min))
+		}
+		if got := flag.Max(c.a, c.b); got != c.max {
+			t.Fatalf("Max(%#x, %#x) = %#x, want %#x", uint32(c.a), uint32(c.b), uint32(got), uint32(c.max))
+		}
+	}
+}
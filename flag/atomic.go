@@ -0,0 +1,105 @@
+package flag
+
+import "sync/atomic"
+
+/*
+`AtomicFlag` is a Flag that can be safely read and updated from multiple goroutines without a mutex.
+
+The zero value is an empty flag ready to use. An AtomicFlag must not be copied after first use.
+
+# Example:
+
+	var status flag.AtomicFlag
+	go status.Set(FlagA)
+	go status.Set(FlagB)
+	// ...
+	status.Has(FlagA | FlagB) // true once both goroutines are done
+*/
+type AtomicFlag struct {
+	v        atomic.Uint32
+	onChange atomic.Pointer[func(old, new Flag)]
+}
+
+// # SetOnChange registers `fn` to be called after every change made through the AtomicFlag's methods
+//
+// `fn` is called once per successful change, after the new value is in place and outside any retry loop,
+// with the value before and after the change. Operations that leave the value as it was, like setting a
+// bit that's already set or a failed `CompareAndSwap`, don't call it. When several goroutines update the flag,
+// calls can arrive in a different order than the changes happened.
+//
+// `fn` runs on the goroutine that made the change, so it must be cheap and must not block.
+// Only one callback is kept: a later call replaces it, and `nil` removes it.
+func (a *AtomicFlag) SetOnChange(fn func(old, new Flag)) {
+	if fn == nil {
+		a.onChange.Store(nil)
+		return
+	}
+	a.onChange.Store(&fn)
+}
+
+// changed calls the `SetOnChange` callback if there is one and `old` differs from `new`.
+func (a *AtomicFlag) changed(old, new Flag) {
+	if fn := a.onChange.Load(); fn != nil && old != new {
+		(*fn)(old, new)
+	}
+}
+
+// # Load atomically returns the current flag value
+func (a *AtomicFlag) Load() Flag {
+	return Flag(a.v.Load())
+}
+
+// # Store atomically replaces the flag value with `flag`
+func (a *AtomicFlag) Store(flag Flag) {
+	a.Swap(flag)
+}
+
+// # Has atomically reports whether the provided flag is set
+func (a *AtomicFlag) Has(flag Flag) bool {
+	return a.Load().Has(flag)
+}
+
+// update applies `fn` to the current value in a CAS loop until it wins and returns the previous value.
+func (a *AtomicFlag) update(fn func(Flag) Flag) Flag {
+	for {
+		old := Flag(a.v.Load())
+		new := fn(old)
+		if a.v.CompareAndSwap(uint32(old), uint32(new)) {
+			a.changed(old, new)
+			return old
+		}
+	}
+}
+
+// # Set atomically sets the provided flag to true/on and returns the previous value
+func (a *AtomicFlag) Set(flag Flag) Flag {
+	return a.update(func(b Flag) Flag { return b | flag })
+}
+
+// # Clear atomically sets the provided flag to false/off and returns the previous value
+func (a *AtomicFlag) Clear(flag Flag) Flag {
+	return a.update(func(b Flag) Flag { return b &^ flag })
+}
+
+// # Toggle atomically toggles the provided flag and returns the previous value
+func (a *AtomicFlag) Toggle(flag Flag) Flag {
+	return a.update(func(b Flag) Flag { return b ^ flag })
+}
+
+// # CompareAndSwap atomically replaces the flag value with `new` only if it is currently `old`
+//
+// returns `true` if the swap happened.
+func (a *AtomicFlag) CompareAndSwap(old, new Flag) bool {
+	if !a.v.CompareAndSwap(uint32(old), uint32(new)) {
+		return false
+	}
+	a.changed(old, new)
+	return true
+}
+
+// # Swap atomically replaces the flag value with `new` and returns the previous value
+func (a *AtomicFlag) Swap(new Flag) Flag {
+	old := Flag(a.v.Swap(uint32(new)))
+	a.changed(old, new)
+	return old
+}
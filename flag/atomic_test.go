@@ -0,0 +1,139 @@
+package flag_test
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestAtomicFlag(t *testing.T) {
+	t.Run("returns previous value", func(t *testing.T) {
+		var a flag.AtomicFlag
+		if old := a.Set(Read | Write); old != 0 {
+			t.Fatalf("Set() = %#b, want 0", uint32(old))
+		}
+		if old := a.Clear(Write); old != Read|Write {
+			t.Fatalf("Clear() = %#b, want %#b", uint32(old), uint32(Read|Write))
+		}
+		if old := a.Toggle(Read | Execute); old != Read {
+			t.Fatalf("Toggle() = %#b, want %#b", uint32(old), uint32(Read))
+		}
+		if a.Load() != Execute || !a.Has(Execute) || a.Has(Read) {
+			t.Fatalf("Load() = %#b, want %#b", uint32(a.Load()), uint32(Execute))
+		}
+		if a.Store(Write); a.Load() != Write {
+			t.Fatalf("Store(Write), Load() = %#b", uint32(a.Load()))
+		}
+	})
+	t.Run("concurrent updates", func(t *testing.T) {
+		var a flag.AtomicFlag
+		var wg sync.WaitGroup
+		for pos := 0; pos < 32; pos++ {
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					a.Set(1 << pos)
+					a.Has(1 << pos)
+				}()
+			}
+		}
+		wg.Wait()
+		if !a.Load().IsAll() {
+			t.Fatalf("after concurrent Set() calls, Load() = %s, want all bits", a.Load())
+		}
+	})
+}
+
+func TestAtomicFlagCAS(t *testing.T) {
+	t.Run("CompareAndSwap()", func(t *testing.T) {
+		var a flag.AtomicFlag
+		a.Store(Read)
+		if a.CompareAndSwap(Write, Execute) || a.Load() != Read {
+			t.Fatalf("CompareAndSwap() with the wrong old value swapped, Load() = %#b", uint32(a.Load()))
+		}
+		if !a.CompareAndSwap(Read, Execute) || a.Load() != Execute {
+			t.Fatalf("CompareAndSwap() with the right old value failed, Load() = %#b", uint32(a.Load()))
+		}
+	})
+	t.Run("Swap()", func(t *testing.T) {
+		var a flag.AtomicFlag
+		a.Store(Read)
+		if old := a.Swap(Write); old != Read || a.Load() != Write {
+			t.Fatalf("Swap(Write) = %#b, Load() = %#b", uint32(old), uint32(a.Load()))
+		}
+	})
+	t.Run("contention", func(t *testing.T) {
+		// every goroutine tries to move the flag from Read to Write, only one can win
+		var a flag.AtomicFlag
+		a.Store(Read)
+		var wg sync.WaitGroup
+		var wins atomic.Int32
+		for i := 0; i < 64; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if a.CompareAndSwap(Read, Write) {
+					wins.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		if wins.Load() != 1 || a.Load() != Write {
+			t.Fatalf("%d goroutines won the CompareAndSwap(), Load() = %#b, want 1 winner", wins.Load(), uint32(a.Load()))
+		}
+	})
+}
+
+func TestAtomicFlagOnChange(t *testing.T) {
+	t.Run("old and new values", func(t *testing.T) {
+		var a flag.AtomicFlag
+		var got [][2]flag.Flag
+		a.SetOnChange(func(old, new flag.Flag) {
+			got = append(got, [2]flag.Flag{old, new})
+		})
+		a.Set(Read)
+		a.Set(Read)    // no-op
+		a.Clear(Write) // no-op
+		a.Toggle(Write)
+		a.CompareAndSwap(Execute, 0) // fails
+		a.CompareAndSwap(Read|Write, Execute)
+		a.Store(Execute) // no-op
+		a.Swap(0)
+		want := [][2]flag.Flag{{0, Read}, {Read, Read | Write}, {Read | Write, Execute}, {Execute, 0}}
+		if !slices.Equal(got, want) {
+			t.Fatalf("callback saw %v, want %v", got, want)
+		}
+		a.SetOnChange(nil)
+		if a.Set(Read); len(got) != len(want) {
+			t.Fatal("callback called after SetOnChange(nil)")
+		}
+	})
+	t.Run("concurrent updates", func(t *testing.T) {
+		var a flag.AtomicFlag
+		var calls atomic.Int32
+		a.SetOnChange(func(old, new flag.Flag) {
+			if old == new || new&^old == 0 {
+				t.Errorf("callback called with old = %#x, new = %#x", uint32(old), uint32(new))
+			}
+			calls.Add(1)
+		})
+		var wg sync.WaitGroup
+		for pos := 0; pos < 32; pos++ {
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					a.Set(1 << pos)
+				}()
+			}
+		}
+		wg.Wait()
+		if n := calls.Load(); n != 32 {
+			t.Fatalf("callback called %d times, want once per bit (32)", n)
+		}
+	})
+}
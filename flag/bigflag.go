@@ -0,0 +1,160 @@
+package flag
+
+import (
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+/*
+`BigFlag` can store any number of true/false (or on/off) values.
+
+It is backed by a slice of `uint64` words that grows as higher positions are set, so flags are addressed by
+bit position instead of by mask. The zero value is an empty flag ready to use.
+
+Unlike the other flag types, assigning a BigFlag (`g := f`) copies a reference to the same words, so setting
+a bit through `g` can change `f` too. Call `Clone` first when a copy will be modified.
+
+# Example:
+
+	const (
+		RuleA = 0
+		RuleB = 70
+		RuleC = 300
+	)
+
+	var f flag.BigFlag
+	f.Set(RuleB).Set(RuleC)
+	f.Has(RuleC) // true
+	f.String()   // "70,300"
+*/
+type BigFlag struct {
+	words []uint64
+}
+
+// # NewBig returns a BigFlag with the bits at all the provided `positions` set to true/on
+func NewBig(positions ...uint) BigFlag {
+	var flag BigFlag
+	for _, pos := range positions {
+		flag.Set(pos)
+	}
+	return flag
+}
+
+// # Clone returns a copy of the flag that doesn't share storage with `b`
+func (b BigFlag) Clone() BigFlag {
+	return BigFlag{words: slices.Clone(b.words)}
+}
+
+// String returns the positions of the set bits in ascending order, separated by commas, e.g. "0,70,300"
+//
+// an empty flag returns "".
+//
+// implements the fmt.Stringer interface
+func (b BigFlag) String() string {
+	var sb strings.Builder
+	for i, w := range b.words {
+		for ; w != 0; w &= w - 1 {
+			if sb.Len() > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.Itoa(i*64 + bits.TrailingZeros64(w)))
+		}
+	}
+	return sb.String()
+}
+
+// # Set sets the bit at `pos` to `1` (on/true), growing the flag if needed
+func (b *BigFlag) Set(pos uint) *BigFlag {
+	if i := int(pos / 64); i >= len(b.words) {
+		b.words = append(b.words, make([]uint64, i+1-len(b.words))...)
+	}
+	b.words[pos/64] |= 1 << (pos % 64)
+	return b
+}
+
+// # Clear sets the bit at `pos` to `0` (off/false)
+//
+// positions past the end of the flag are already clear, so they are left alone.
+func (b *BigFlag) Clear(pos uint) *BigFlag {
+	if pos/64 < uint(len(b.words)) {
+		b.words[pos/64] &^= 1 << (pos % 64)
+	}
+	return b
+}
+
+// # Toggle toggles the bit at `pos`, growing the flag if needed
+func (b *BigFlag) Toggle(pos uint) *BigFlag {
+	if b.Has(pos) {
+		return b.Clear(pos)
+	}
+	return b.Set(pos)
+}
+
+// # Has returns `true` if the bit at `pos` is set
+func (b BigFlag) Has(pos uint) bool {
+	return pos/64 < uint(len(b.words)) && b.words[pos/64]&(1<<(pos%64)) != 0
+}
+
+// # Count returns the number of set bits (flags that are true/on)
+func (b BigFlag) Count() int {
+	var n int
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// # Equal returns `true` if both flags have exactly the same bits set, however many words each has grown to
+func (b BigFlag) Equal(other BigFlag) bool {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	for i, w := range long {
+		if i < len(short) && w != short[i] || i >= len(short) && w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// # Union returns a new BigFlag with the bits of both `b` and `other` set (OR)
+//
+// the operands may have different lengths; neither is modified.
+func (b BigFlag) Union(other BigFlag) BigFlag {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	var words = make([]uint64, len(long))
+	copy(words, long)
+	for i, w := range short {
+		words[i] |= w
+	}
+	return BigFlag{words: words}
+}
+
+// # Intersection returns a new BigFlag with only the bits set in both `b` and `other` (AND)
+//
+// the operands may have different lengths; neither is modified.
+func (b BigFlag) Intersection(other BigFlag) BigFlag {
+	var words = make([]uint64, min(len(b.words), len(other.words)))
+	for i := range words {
+		words[i] = b.words[i] & other.words[i]
+	}
+	return BigFlag{words: words}
+}
+
+// # Difference returns a new BigFlag with the bits of `b` that are not set in `other` (AND NOT)
+//
+// the operands may have different lengths; neither is modified.
+func (b BigFlag) Difference(other BigFlag) BigFlag {
+	var words = make([]uint64, len(b.words))
+	copy(words, b.words)
+	for i := range min(len(words), len(other.words)) {
+		words[i] &^= other.words[i]
+	}
+	return BigFlag{words: words}
+}
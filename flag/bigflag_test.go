@@ -0,0 +1,79 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestBigFlag(t *testing.T) {
+	t.Run("word boundaries", func(t *testing.T) {
+		var f flag.BigFlag
+		f.Set(0).Set(63).Set(64).Set(300)
+		for pos, want := range map[uint]bool{0: true, 1: false, 63: true, 64: true, 65: false, 300: true, 1000: false} {
+			if got := f.Has(pos); got != want {
+				t.Fatalf("Has(%d) = %t, want %t", pos, got, want)
+			}
+		}
+		if f.Count() != 4 || f.String() != "0,63,64,300" {
+			t.Fatalf("Count() = %d, String() = %q, want 4, %q", f.Count(), f.String(), "0,63,64,300")
+		}
+	})
+	t.Run("Clear() and Toggle()", func(t *testing.T) {
+		var f = flag.NewBig(1, 64)
+		f.Clear(64).Clear(5000).Toggle(1).Toggle(130)
+		if got := f.String(); got != "130" {
+			t.Fatalf("String() = %q, want %q", got, "130")
+		}
+		if f.Toggle(130); f.Count() != 0 || f.String() != "" {
+			t.Fatalf("Toggle(130) twice = %q, want empty", f.String())
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		var f flag.BigFlag
+		if f.Has(0) || f.Count() != 0 || f.String() != "" || !f.Equal(flag.NewBig()) {
+			t.Fatalf("zero BigFlag = %q, want empty", f.String())
+		}
+	})
+	t.Run("different lengths", func(t *testing.T) {
+		var short, long = flag.NewBig(1, 2, 63), flag.NewBig(2, 63, 64, 200)
+		for name, c := range map[string]struct {
+			got  flag.BigFlag
+			want string
+		}{
+			"Union()":                {short.Union(long), "1,2,63,64,200"},
+			"Union() swapped":        {long.Union(short), "1,2,63,64,200"},
+			"Intersection()":         {short.Intersection(long), "2,63"},
+			"Intersection() swapped": {long.Intersection(short), "2,63"},
+			"Difference()":           {short.Difference(long), "1"},
+			"Difference() swapped":   {long.Difference(short), "64,200"},
+		} {
+			if got := c.got.String(); got != c.want {
+				t.Fatalf("%s = %q, want %q", name, got, c.want)
+			}
+		}
+		if short.String() != "1,2,63" || long.String() != "2,63,64,200" {
+			t.Fatalf("set algebra modified its operands: %q, %q", short.String(), long.String())
+		}
+	})
+	t.Run("Clone()", func(t *testing.T) {
+		var f = flag.NewBig(70)
+		g := f.Clone()
+		g.Set(3).Clear(70)
+		if f.String() != "70" || g.String() != "3" {
+			t.Fatalf("after changing a Clone(), f = %q, clone = %q, want %q, %q", f.String(), g.String(), "70", "3")
+		}
+		if got := flag.NewBig().Clone(); got.Count() != 0 {
+			t.Fatalf("Clone() of an empty BigFlag = %q, want empty", got.String())
+		}
+	})
+	t.Run("Equal()", func(t *testing.T) {
+		var f = flag.NewBig(3, 200)
+		if f.Clear(200); !f.Equal(flag.NewBig(3)) || !flag.NewBig(3).Equal(f) {
+			t.Fatal("Equal() = false for flags that only differ in trailing empty words")
+		}
+		if f.Equal(flag.NewBig(3, 4)) || flag.NewBig(3, 100).Equal(f) {
+			t.Fatal("Equal() = true for different flags")
+		}
+	})
+}
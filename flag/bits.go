@@ -0,0 +1,283 @@
+package flag
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// # LowestSetBit returns the position (0-31) of the lowest set bit
+//
+// returns -1 if no bits are set.
+func (b Flag) LowestSetBit() int {
+	if b == 0 {
+		return -1
+	}
+	return bits.TrailingZeros32(uint32(b))
+}
+
+// # HighestSetBit returns the position (0-31) of the highest set bit
+//
+// returns -1 if no bits are set.
+func (b Flag) HighestSetBit() int {
+	if b == 0 {
+		return -1
+	}
+	return 31 - bits.LeadingZeros32(uint32(b))
+}
+
+// # LeadingZeros returns the number of unset bits above the highest set bit
+//
+// returns 32 if no bits are set, like `bits.LeadingZeros32`.
+func (b Flag) LeadingZeros() int {
+	return bits.LeadingZeros32(uint32(b))
+}
+
+// # TrailingZeros returns the number of unset bits below the lowest set bit
+//
+// returns 32 if no bits are set, like `bits.TrailingZeros32`, where `LowestSetBit` returns -1.
+func (b Flag) TrailingZeros() int {
+	return bits.TrailingZeros32(uint32(b))
+}
+
+// bit returns the single-bit Flag for `pos`, panicking if `pos` is not in the range 0-31.
+func bit(pos int) Flag {
+	if pos < 0 || pos > 31 {
+		panic(fmt.Sprintf("flag: bit position %d out of range [0, 32)", pos))
+	}
+	return 1 << pos
+}
+
+// # SetBit sets the bit at position `pos` to `1` (on/true)
+//
+// panics if `pos` is not in the range 0-31.
+func (b *Flag) SetBit(pos int) *Flag {
+	*b |= bit(pos)
+	return b
+}
+
+// # ClearBit sets the bit at position `pos` to `0` (off/false)
+//
+// panics if `pos` is not in the range 0-31.
+func (b *Flag) ClearBit(pos int) *Flag {
+	*b &^= bit(pos)
+	return b
+}
+
+// # ToggleBit toggles the bit at position `pos`
+//
+// panics if `pos` is not in the range 0-31.
+func (b *Flag) ToggleBit(pos int) *Flag {
+	*b ^= bit(pos)
+	return b
+}
+
+// # TestBit returns `true` if the bit at position `pos` is set
+//
+// panics if `pos` is not in the range 0-31.
+func (b Flag) TestBit(pos int) bool {
+	return b&bit(pos) != 0
+}
+
+// # Reverse returns a new Flag with the order of all 32 bits reversed
+//
+// bit 0 becomes bit 31, bit 1 becomes bit 30, and so on.
+func (b Flag) Reverse() Flag {
+	return Flag(bits.Reverse32(uint32(b)))
+}
+
+// # RotateLeft returns a new Flag with the bits rotated left by `n` positions
+//
+// bits shifted out of the top wrap around to the bottom. `n` is reduced modulo 32, and a negative `n` rotates right.
+func (b Flag) RotateLeft(n int) Flag {
+	return Flag(bits.RotateLeft32(uint32(b), n%32))
+}
+
+// # RotateRight returns a new Flag with the bits rotated right by `n` positions
+//
+// bits shifted out of the bottom wrap around to the top. `n` is reduced modulo 32, and a negative `n` rotates left.
+func (b Flag) RotateRight(n int) Flag {
+	return Flag(bits.RotateLeft32(uint32(b), -(n % 32)))
+}
+
+// # ShiftLeft returns a new Flag with the bits shifted left by `n` positions
+//
+// unlike `RotateLeft`, bits shifted past bit 31 are dropped, so shifting by 32 or more returns zero.
+// A negative `n` shifts right, matching how `RotateLeft` handles it.
+func (b Flag) ShiftLeft(n int) Flag {
+	if n < 0 {
+		return b >> uint(-n)
+	}
+	return b << n
+}
+
+// # ShiftRight returns a new Flag with the bits shifted right by `n` positions
+//
+// unlike `RotateRight`, bits shifted past bit 0 are dropped, so shifting by 32 or more returns zero.
+// A negative `n` shifts left, matching how `RotateRight` handles it.
+func (b Flag) ShiftRight(n int) Flag {
+	if n < 0 {
+		return b << uint(-n)
+	}
+	return b >> n
+}
+
+// rangeMask returns a Flag with the bits in `[lo, hi)` set, panicking unless `0 <= lo <= hi <= 32`.
+func rangeMask(lo, hi int) Flag {
+	if lo < 0 || hi > 32 || lo > hi {
+		panic(fmt.Sprintf("flag: invalid bit range [%d, %d), want 0 <= lo <= hi <= 32", lo, hi))
+	}
+	return Flag((uint64(1)<<hi - 1) &^ (uint64(1)<<lo - 1))
+}
+
+// # MaskUpTo returns a Flag with bits `0` to `n-1` set, so `MaskUpTo(0)` is empty and `MaskUpTo(32)` is all ones
+//
+// panics unless `0 <= n <= 32`.
+func MaskUpTo(n int) Flag {
+	return rangeMask(0, n)
+}
+
+// # MaskFrom returns a Flag with bits `n` to `31` set, so `MaskFrom(0)` is all ones and `MaskFrom(32)` is empty
+//
+// panics unless `0 <= n <= 32`.
+func MaskFrom(n int) Flag {
+	return rangeMask(n, 32)
+}
+
+// # SetRange sets every bit in the half-open range `[lo, hi)` to `1` (on/true)
+//
+// an empty range (`lo == hi`) does nothing. Panics unless `0 <= lo <= hi <= 32`.
+func (b *Flag) SetRange(lo, hi int) *Flag {
+	*b |= rangeMask(lo, hi)
+	return b
+}
+
+// # ClearRange sets every bit in the half-open range `[lo, hi)` to `0` (off/false)
+//
+// an empty range (`lo == hi`) does nothing. Panics unless `0 <= lo <= hi <= 32`.
+func (b *Flag) ClearRange(lo, hi int) *Flag {
+	*b &^= rangeMask(lo, hi)
+	return b
+}
+
+// # ToggleRange toggles every bit in the half-open range `[lo, hi)`
+//
+// an empty range (`lo == hi`) does nothing. Panics unless `0 <= lo <= hi <= 32`.
+func (b *Flag) ToggleRange(lo, hi int) *Flag {
+	*b ^= rangeMask(lo, hi)
+	return b
+}
+
+// # SwapBits exchanges the bits at positions `i` and `j`, leaving every other bit unchanged
+//
+// panics if `i` or `j` is not in the range 0-31.
+func (b *Flag) SwapBits(i, j int) *Flag {
+	bi, bj := bit(i), bit(j)
+	if (*b&bi != 0) != (*b&bj != 0) {
+		*b ^= bi | bj // the bits differ, so flipping both swaps them
+	}
+	return b
+}
+
+// # IsSingleBit returns `true` if exactly one bit is set (`b` is a power of two)
+func (b Flag) IsSingleBit() bool {
+	return b != 0 && b&(b-1) == 0
+}
+
+// # Parity returns 0 if an even number of bits are set and 1 if an odd number are set
+func (b Flag) Parity() int {
+	return b.Count() & 1
+}
+
+// # SetSingle sets the provided flag, but only if it is a single bit
+//
+// returns an error and leaves `b` unchanged if `flag` is zero or has more than one bit set.
+func (b *Flag) SetSingle(flag Flag) error {
+	if !flag.IsSingleBit() {
+		return fmt.Errorf("flag: %s is not a single bit", flag.Hex())
+	}
+	*b |= flag
+	return nil
+}
+
+// # BitLen returns the minimum number of bits needed to represent the flag
+//
+// returns 0 for an empty flag and 32 when bit 31 is set.
+func (b Flag) BitLen() int {
+	return bits.Len32(uint32(b))
+}
+
+// # LowestBitValue returns a Flag with only the lowest set bit of `b`, or 0 if no bits are set
+func (b Flag) LowestBitValue() Flag {
+	return b & -b
+}
+
+// # HighestBitValue returns a Flag with only the highest set bit of `b`, or 0 if no bits are set
+func (b Flag) HighestBitValue() Flag {
+	if b == 0 {
+		return 0
+	}
+	return 1 << b.HighestSetBit()
+}
+
+// # PopLowest clears the lowest set bit and returns it as a single-bit Flag
+//
+// returns `(0, false)` if no bits are set, so all bits can be consumed in ascending order with:
+//
+//	for bit, ok := f.PopLowest(); ok; bit, ok = f.PopLowest() {
+//		...
+//	}
+func (b *Flag) PopLowest() (Flag, bool) {
+	lowest := b.LowestBitValue()
+	*b &^= lowest
+	return lowest, lowest != 0
+}
+
+// # ClearLowest clears the lowest set bit, doing nothing if no bits are set
+//
+// like `PopLowest`, for when the removed bit isn't needed.
+func (b *Flag) ClearLowest() *Flag {
+	*b &^= b.LowestBitValue()
+	return b
+}
+
+// # ClearHighest clears the highest set bit, doing nothing if no bits are set
+func (b *Flag) ClearHighest() *Flag {
+	*b &^= b.HighestBitValue()
+	return b
+}
+
+// # TestRange returns `true` if every bit in the half-open range `[lo, hi)` is set
+//
+// an empty range (`lo == hi`) is always `true`. Panics unless `0 <= lo <= hi <= 32`, like `SetRange`.
+func (b Flag) TestRange(lo, hi int) bool {
+	mask := rangeMask(lo, hi)
+	return b&mask == mask
+}
+
+// # NextSetBit returns the position of the lowest set bit at or after position `from`
+//
+// returns -1 if there is none, including when `from` is 32 or more. A negative `from` searches from bit 0.
+func (b Flag) NextSetBit(from int) int {
+	if from >= 32 {
+		return -1
+	}
+	if from > 0 {
+		b &^= 1<<from - 1 // ignore the bits below from
+	}
+	return b.LowestSetBit()
+}
+
+// # MostSignificantDifference returns the position of the highest bit where `b` and `other` differ
+//
+// returns -1 if they are equal.
+func (b Flag) MostSignificantDifference(other Flag) int {
+	return (b ^ other).HighestSetBit()
+}
+
+// # Normalize clears every bit at position `width` or higher, keeping only the low `width` bits
+//
+// useful after parsing untrusted input into a flag that only uses some of its bits.
+// `Normalize(32)` does nothing and `Normalize(0)` clears everything. Panics unless `0 <= width <= 32`.
+func (b *Flag) Normalize(width int) *Flag {
+	return b.ClearRange(width, 32)
+}
@@ -0,0 +1,405 @@
+package flag_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestSetBitPositions(t *testing.T) {
+	for f, want := range map[flag.Flag][2]int{
+		0:          {-1, -1},
+		1:          {0, 0},
+		1 << 31:    {31, 31},
+		0b0101000:  {3, 5},
+		0x80000001: {0, 31},
+	} {
+		if lo, hi := f.LowestSetBit(), f.HighestSetBit(); lo != want[0] || hi != want[1] {
+			t.Fatalf("Flag(%#x): LowestSetBit() = %d, HighestSetBit() = %d, want %d, %d", uint32(f), lo, hi, want[0], want[1])
+		}
+	}
+}
+
+func TestZeros(t *testing.T) {
+	for f, want := range map[flag.Flag][2]int{
+		0:          {32, 32},
+		1:          {31, 0},
+		1 << 31:    {0, 31},
+		0b0101000:  {26, 3},
+		0x80000001: {0, 0},
+	} {
+		if lead, trail := f.LeadingZeros(), f.TrailingZeros(); lead != want[0] || trail != want[1] {
+			t.Fatalf("Flag(%#x): LeadingZeros() = %d, TrailingZeros() = %d, want %d, %d", uint32(f), lead, trail, want[0], want[1])
+		}
+	}
+}
+
+func TestBitIndex(t *testing.T) {
+	t.Run("positions 0 and 31", func(t *testing.T) {
+		var f = flag.New()
+		f.SetBit(0).SetBit(31)
+		if f != 0x80000001 || !f.TestBit(0) || !f.TestBit(31) || f.TestBit(1) {
+			t.Fatalf("SetBit(0).SetBit(31) = %#x", uint32(f))
+		}
+		f.ClearBit(0).ToggleBit(31).ToggleBit(5)
+		if f != 1<<5 {
+			t.Fatalf("ClearBit(0).ToggleBit(31).ToggleBit(5) = %#x, want 0x20", uint32(f))
+		}
+	})
+	t.Run("out of range", func(t *testing.T) {
+		for _, pos := range []int{-1, 32} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("SetBit(%d) did not panic", pos)
+					}
+				}()
+				var f = flag.New()
+				f.SetBit(pos)
+			}()
+		}
+		defer func() {
+			if recover() == nil {
+				t.Fatal("TestBit(32) did not panic")
+			}
+		}()
+		flag.New().TestBit(32)
+	})
+}
+
+func TestReverse(t *testing.T) {
+	for f, want := range map[flag.Flag]flag.Flag{
+		0:          0,
+		1:          1 << 31,
+		0b0110:     0x60000000,
+		0xFFFFFFFF: 0xFFFFFFFF,
+	} {
+		if got := f.Reverse(); got != want {
+			t.Fatalf("Flag(%#x).Reverse() = %#x, want %#x", uint32(f), uint32(got), uint32(want))
+		}
+		if got := f.Reverse().Reverse(); got != f {
+			t.Fatalf("Flag(%#x).Reverse().Reverse() = %#x", uint32(f), uint32(got))
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	var f flag.Flag = 0x80000001
+	for _, c := range []struct {
+		n           int
+		left, right flag.Flag
+	}{
+		{0, f, f},
+		{1, 0x00000003, 0xC0000000},
+		{32, f, f},
+		{33, 0x00000003, 0xC0000000},
+		{-1, 0xC0000000, 0x00000003},
+		{-65, 0xC0000000, 0x00000003},
+	} {
+		if got := f.RotateLeft(c.n); got != c.left {
+			t.Fatalf("RotateLeft(%d) = %#x, want %#x", c.n, uint32(got), uint32(c.left))
+		}
+		if got := f.RotateRight(c.n); got != c.right {
+			t.Fatalf("RotateRight(%d) = %#x, want %#x", c.n, uint32(got), uint32(c.right))
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	var f flag.Flag = 0x80000001
+	for _, c := range []struct {
+		n           int
+		left, right flag.Flag
+	}{
+		{0, f, f},
+		{1, 0x00000002, 0x40000000},
+		{4, 0x00000010, 0x08000000},
+		{31, 0x80000000, 0x00000001},
+		{32, 0, 0},
+		{-1, 0x40000000, 0x00000002},
+		{math.MinInt, 0, 0},
+	} {
+		if got := f.ShiftLeft(c.n); got != c.left {
+			t.Fatalf("ShiftLeft(%d) = %#x, want %#x", c.n, uint32(got), uint32(c.left))
+		}
+		if got := f.ShiftRight(c.n); got != c.right {
+			t.Fatalf("ShiftRight(%d) = %#x, want %#x", c.n, uint32(got), uint32(c.right))
+		}
+	}
+}
+
+func TestRanges(t *testing.T) {
+	t.Run("SetRange()", func(t *testing.T) {
+		for _, c := range []struct {
+			lo, hi int
+			want   flag.Flag
+		}{
+			{0, 32, 0xFFFFFFFF},
+			{4, 12, 0x00000FF0},
+			{31, 32, 0x80000000},
+			{5, 5, 0},
+			{32, 32, 0},
+		} {
+			var f = flag.New()
+			if f.SetRange(c.lo, c.hi); f != c.want {
+				t.Fatalf("SetRange(%d, %d) = %#x, want %#x", c.lo, c.hi, uint32(f), uint32(c.want))
+			}
+		}
+	})
+	t.Run("ClearRange() and ToggleRange()", func(t *testing.T) {
+		var f = flag.New()
+		f.SetAll().ClearRange(4, 12)
+		if f != 0xFFFFF00F {
+			t.Fatalf("ClearRange(4, 12) = %#x, want 0xfffff00f", uint32(f))
+		}
+		f.ToggleRange(0, 8).ToggleRange(3, 3)
+		if f != 0xFFFFF0F0 {
+			t.Fatalf("ToggleRange(0, 8) = %#x, want 0xfffff0f0", uint32(f))
+		}
+		if f.ToggleRange(0, 32); f != 0x00000F0F {
+			t.Fatalf("ToggleRange(0, 32) = %#x, want 0xf0f", uint32(f))
+		}
+	})
+	t.Run("invalid ranges", func(t *testing.T) {
+		for _, r := range [][2]int{{-1, 4}, {4, 33}, {8, 4}} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatalf("SetRange(%d, %d) did not panic", r[0], r[1])
+					}
+				}()
+				var f = flag.New()
+				f.SetRange(r[0], r[1])
+			}()
+		}
+	})
+}
+
+func TestMasks(t *testing.T) {
+	for n, want := range map[int][2]flag.Flag{
+		0:  {0, 0xFFFFFFFF},
+		1:  {1, 0xFFFFFFFE},
+		31: {0x7FFFFFFF, 1 << 31},
+		32: {0xFFFFFFFF, 0},
+	} {
+		if upTo, from := flag.MaskUpTo(n), flag.MaskFrom(n); upTo != want[0] || from != want[1] {
+			t.Fatalf("MaskUpTo(%d) = %#x, MaskFrom(%d) = %#x, want %#x, %#x", n, uint32(upTo), n, uint32(from), uint32(want[0]), uint32(want[1]))
+		}
+	}
+	for _, n := range []int{-1, 33} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("MaskUpTo(%d) did not panic", n)
+				}
+			}()
+			flag.MaskUpTo(n)
+		}()
+	}
+}
+
+func TestSwapBits(t *testing.T) {
+	t.Run("different bits", func(t *testing.T) {
+		var f flag.Flag = 0b0001
+		if f.SwapBits(0, 3); f != 0b1000 {
+			t.Fatalf("SwapBits(0, 3) = %#b, want 0b1000", uint32(f))
+		}
+		if f.SwapBits(31, 3); f != 1<<31 {
+			t.Fatalf("SwapBits(31, 3) = %#x, want 0x80000000", uint32(f))
+		}
+	})
+	t.Run("equal bits", func(t *testing.T) {
+		var f flag.Flag = 0b1001
+		if f.SwapBits(0, 3).SwapBits(1, 2).SwapBits(4, 4); f != 0b1001 {
+			t.Fatalf("SwapBits() of equal bits = %#b, want 0b1001", uint32(f))
+		}
+	})
+	t.Run("out of range", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("SwapBits(0, 32) did not panic")
+			}
+		}()
+		var f = flag.New()
+		f.SwapBits(0, 32)
+	})
+}
+
+func TestSingleBit(t *testing.T) {
+	for f, want := range map[flag.Flag]bool{
+		0:       false,
+		1:       true,
+		1 << 31: true,
+		3:       false,
+		0x8001:  false,
+	} {
+		if got := f.IsSingleBit(); got != want {
+			t.Fatalf("Flag(%#x).IsSingleBit() = %t, want %t", uint32(f), got, want)
+		}
+		var b flag.Flag = 1 << 4
+		if err := b.SetSingle(f); (err == nil) != want {
+			t.Fatalf("SetSingle(%#x) = %v", uint32(f), err)
+		}
+		if want && b != 1<<4|f || !want && b != 1<<4 {
+			t.Fatalf("SetSingle(%#x): b = %#x", uint32(f), uint32(b))
+		}
+	}
+}
+
+func TestParity(t *testing.T) {
+	for f, want := range map[flag.Flag]int{
+		0:          0,
+		1 << 7:     1,
+		0b1001:     0,
+		0b1011:     1,
+		0xFFFFFFFF: 0,
+	} {
+		if got := f.Parity(); got != want {
+			t.Fatalf("Flag(%#x).Parity() = %d, want %d", uint32(f), got, want)
+		}
+	}
+}
+
+func TestBitLen(t *testing.T) {
+	for f, want := range map[flag.Flag]int{
+		0:        0,
+		1:        1,
+		0b101010: 6,
+		1 << 31:  32,
+	} {
+		if got := f.BitLen(); got != want {
+			t.Fatalf("Flag(%#x).BitLen() = %d, want %d", uint32(f), got, want)
+		}
+	}
+}
+
+func TestBitValues(t *testing.T) {
+	for f, want := range map[flag.Flag][2]flag.Flag{
+		0:          {0, 0},
+		1 << 9:     {1 << 9, 1 << 9},
+		0b0101000:  {0b1000, 0b0100000},
+		0x80000001: {1, 1 << 31},
+	} {
+		if lo, hi := f.LowestBitValue(), f.HighestBitValue(); lo != want[0] || hi != want[1] {
+			t.Fatalf("Flag(%#x): LowestBitValue() = %#x, HighestBitValue() = %#x, want %#x, %#x", uint32(f), uint32(lo), uint32(hi), uint32(want[0]), uint32(want[1]))
+		}
+	}
+}
+
+func TestPopLowest(t *testing.T) {
+	var f flag.Flag = 0x80000005
+	var got []flag.Flag
+	for bit, ok := f.PopLowest(); ok; bit, ok = f.PopLowest() {
+		got = append(got, bit)
+	}
+	if !slices.Equal(got, []flag.Flag{1, 4, 1 << 31}) || f != 0 {
+		t.Fatalf("PopLowest() returned %v, f = %#x, want [1 4 1<<31] and 0", got, uint32(f))
+	}
+	if bit, ok := f.PopLowest(); bit != 0 || ok {
+		t.Fatalf("PopLowest() on an empty flag = %#x, %t, want 0, false", uint32(bit), ok)
+	}
+}
+
+func TestClearLowestHighest(t *testing.T) {
+	for f, want := range map[flag.Flag][2]flag.Flag{
+		0:          {0, 0},
+		1 << 9:     {0, 0},
+		0b0101000:  {0b0100000, 0b0001000},
+		0x80000001: {1 << 31, 1},
+	} {
+		var lo, hi = f, f
+		if lo.ClearLowest(); lo != want[0] {
+			t.Fatalf("Flag(%#x).ClearLowest() = %#x, want %#x", uint32(f), uint32(lo), uint32(want[0]))
+		}
+		if hi.ClearHighest(); hi != want[1] {
+			t.Fatalf("Flag(%#x).ClearHighest() = %#x, want %#x", uint32(f), uint32(hi), uint32(want[1]))
+		}
+	}
+}
+
+func TestTestRange(t *testing.T) {
+	var f flag.Flag = 0x00000FF0
+	for _, c := range []struct {
+		lo, hi int
+		want   bool
+	}{
+		{4, 12, true},
+		{5, 8, true},
+		{3, 12, false},
+		{4, 13, false},
+		{0, 0, true},
+		{20, 20, true},
+	} {
+		if got := f.TestRange(c.lo, c.hi); got != c.want {
+			t.Fatalf("TestRange(%d, %d) = %t, want %t", c.lo, c.hi, got, c.want)
+		}
+	}
+	if !flag.Flag(0xFFFFFFFF).TestRange(0, 32) {
+		t.Fatal("Flag(0xFFFFFFFF).TestRange(0, 32) = false, want true")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("TestRange(0, 33) did not panic")
+		}
+	}()
+	f.TestRange(0, 33)
+}
+
+func TestNextSetBit(t *testing.T) {
+	var f flag.Flag = 0x80000025 // bits 0, 2, 5 and 31
+	for from, want := range map[int]int{
+		-3: 0,
+		0:  0,
+		1:  2,
+		2:  2,
+		3:  5,
+		6:  31,
+		31: 31,
+		32: -1,
+	} {
+		if got := f.NextSetBit(from); got != want {
+			t.Fatalf("NextSetBit(%d) = %d, want %d", from, got, want)
+		}
+	}
+	if got := flag.Flag(0b101).NextSetBit(3); got != -1 {
+		t.Fatalf("NextSetBit() past the highest set bit = %d, want -1", got)
+	}
+}
+
+func TestMostSignificantDifference(t *testing.T) {
+	var f flag.Flag = 0x80000025
+	for other, want := range map[flag.Flag]int{
+		f:              -1,
+		f ^ 1:          0,
+		f ^ 0b1010:     3,
+		f &^ (1 << 31): 31,
+	} {
+		if got := f.MostSignificantDifference(other); got != want {
+			t.Fatalf("MostSignificantDifference(%#x) = %d, want %d", uint32(other), got, want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for width, want := range map[int]flag.Flag{
+		0:  0,
+		4:  0xF,
+		12: 0xEEF,
+		31: 0x7EEEEEEF,
+		32: 0xFEEEEEEF,
+	} {
+		var f flag.Flag = 0xFEEEEEEF
+		if f.Normalize(width); f != want {
+			t.Fatalf("Normalize(%d) = %#x, want %#x", width, uint32(f), uint32(want))
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Normalize(33) did not panic")
+		}
+	}()
+	var f = flag.New()
+	f.Normalize(33)
+}
@@ -0,0 +1,71 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+`Builder` accumulates flags and checks them against rules before producing the final `Flag`.
+
+# Example:
+
+	f, err := flag.NewBuilder().
+		Exclusive(ReadOnly, WriteOnly).
+		With(ReadOnly).
+		WithIf(verbose, Verbose).
+		Build()
+*/
+type Builder struct {
+	flag      Flag
+	exclusive [][2]Flag
+}
+
+// # NewBuilder returns an empty Builder with no rules
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// # With sets `flag` on the flag being built
+func (bl *Builder) With(flag Flag) *Builder {
+	bl.flag |= flag
+	return bl
+}
+
+// # WithIf sets `flag` only if `cond` is true
+func (bl *Builder) WithIf(cond bool, flag Flag) *Builder {
+	if cond {
+		bl.flag |= flag
+	}
+	return bl
+}
+
+// # Without clears `flag` from the flag being built
+func (bl *Builder) Without(flag Flag) *Builder {
+	bl.flag &^= flag
+	return bl
+}
+
+// # Exclusive adds a rule that `a` and `b` can't both be present when `Build` is called
+//
+// for multi-bit flags, "present" means any of their bits is set.
+func (bl *Builder) Exclusive(a, b Flag) *Builder {
+	bl.exclusive = append(bl.exclusive, [2]Flag{a, b})
+	return bl
+}
+
+// # Build returns the accumulated flag, or an error describing every `Exclusive` rule it breaks
+//
+// rules are only checked here, so `With` and `Without` can be called in any order.
+func (bl *Builder) Build() (Flag, error) {
+	var errs []error
+	for _, pair := range bl.exclusive {
+		if bl.flag.HasAnyIn(pair[0]) && bl.flag.HasAnyIn(pair[1]) {
+			errs = append(errs, fmt.Errorf("flag: %s and %s are mutually exclusive", pair[0].Hex(), pair[1].Hex()))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+	return bl.flag, nil
+}
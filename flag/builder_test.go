@@ -0,0 +1,40 @@
+package flag_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("valid combination", func(t *testing.T) {
+		f, err := flag.NewBuilder().
+			Exclusive(1, 2).
+			With(1).
+			With(4).
+			WithIf(false, 2).
+			WithIf(true, 8).
+			Build()
+		if err != nil || f != 0b1101 {
+			t.Fatalf("Build() = %#b, %v, want 0b1101, nil", uint32(f), err)
+		}
+	})
+	t.Run("Without() resolves a conflict", func(t *testing.T) {
+		f, err := flag.NewBuilder().Exclusive(1, 2).With(3).Without(2).Build()
+		if err != nil || f != 1 {
+			t.Fatalf("Build() = %#b, %v, want 0b1, nil", uint32(f), err)
+		}
+	})
+	t.Run("exclusive flags", func(t *testing.T) {
+		f, err := flag.NewBuilder().Exclusive(1, 2).Exclusive(4, 0b110000).With(0b10111).Build()
+		if err == nil || f != 0 {
+			t.Fatalf("Build() = %#b, %v, want 0, error", uint32(f), err)
+		}
+		for _, want := range []string{"0x1 and 0x2", "0x4 and 0x30"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Fatalf("Build() error = %q, want it to mention %q", err, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package flag
+
+import "strings"
+
+/*
+`CLIValue` lets a Flag be set from the command line by name, e.g. `--features=Read,Write`.
+
+It implements the `flag.Value` interface of the standard library's flag package. Since that package is also
+named `flag`, import one of them under another name:
+
+	import (
+		stdflag "flag"
+
+		"github.com/chasecarlson1/go-bitflags/flag"
+	)
+
+	var features flag.Flag
+	stdflag.Var(flag.NewCLIValue(&features, names), "features", "comma separated features to enable")
+*/
+type CLIValue struct {
+	flag  *Flag
+	names *FlagSet
+}
+
+// # NewCLIValue returns a CLIValue that sets bits of `b` by looking up names in `names`
+func NewCLIValue(b *Flag, names *FlagSet) *CLIValue {
+	return &CLIValue{flag: b, names: names}
+}
+
+// # String returns the names of the set flags, as rendered by `FlagSet.String`
+func (v *CLIValue) String() string {
+	if v == nil || v.flag == nil || v.names == nil {
+		return ""
+	}
+	return v.names.String(*v.flag)
+}
+
+// # Set parses names separated by "," or "|" and sets their bits.
+//
+// bits are added to the ones already set, so repeating the option accumulates flags.
+func (v *CLIValue) Set(s string) error {
+	flag, err := v.names.Parse(strings.ReplaceAll(s, ",", "|"))
+	if err != nil {
+		return err
+	}
+	*v.flag |= flag
+	return nil
+}
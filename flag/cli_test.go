@@ -0,0 +1,43 @@
+package flag_test
+
+import (
+	stdflag "flag"
+	"io"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestCLIValue(t *testing.T) {
+	var names = newPerms(t)
+	t.Run("flag.Var()", func(t *testing.T) {
+		var features flag.Flag
+		var cmd = stdflag.NewFlagSet("cmd", stdflag.ContinueOnError)
+		cmd.Var(flag.NewCLIValue(&features, names), "features", "features to enable")
+		if err := cmd.Parse([]string{"--features=Read,Write", "--features", "Execute"}); err != nil {
+			t.Fatal(err)
+		}
+		if features != Read|Write|Execute {
+			t.Fatalf("--features=Read,Write --features Execute = %#b, want %#b", uint32(features), uint32(Read|Write|Execute))
+		}
+		if got := cmd.Lookup("features").Value.String(); got != "Read|Write|Execute" {
+			t.Fatalf("Value.String() = %q, want %q", got, "Read|Write|Execute")
+		}
+	})
+	t.Run("unknown name", func(t *testing.T) {
+		var features flag.Flag
+		var cmd = stdflag.NewFlagSet("cmd", stdflag.ContinueOnError)
+		cmd.SetOutput(io.Discard)
+		cmd.Var(flag.NewCLIValue(&features, names), "features", "features to enable")
+		if err := cmd.Parse([]string{"--features=Read|Delete"}); err == nil {
+			t.Fatal("--features=Read|Delete did not return an error")
+		}
+	})
+	t.Run("PrintDefaults()", func(t *testing.T) {
+		var features flag.Flag
+		var cmd = stdflag.NewFlagSet("cmd", stdflag.ContinueOnError)
+		cmd.SetOutput(io.Discard)
+		cmd.Var(flag.NewCLIValue(&features, names), "features", "features to enable")
+		cmd.PrintDefaults() // must not panic on the zero CLIValue
+	})
+}
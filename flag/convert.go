@@ -0,0 +1,101 @@
+package flag
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// # NewFromUint returns a Flag with the bits of `n`
+//
+// same as `flag.Flag(n)`, but makes the integer to flag boundary explicit.
+func NewFromUint(n uint32) Flag {
+	return Flag(n)
+}
+
+// # AsUint32 returns the flag as a plain `uint32`
+//
+// same as `uint32(b)`, but makes the flag to integer boundary explicit.
+func (b Flag) AsUint32() uint32 {
+	return uint32(b)
+}
+
+// # Bools returns the flag as an array of bools, where index `i` is true when bit `i` is set.
+//
+// index 0 is the least-significant bit.
+func (b Flag) Bools() [32]bool {
+	var bools [32]bool
+	for pos := range b.Bits() {
+		bools[pos] = true
+	}
+	return bools
+}
+
+// # FromBools returns a Flag with bit `i` set for every true index `i` of `bools`.
+//
+// index 0 is the least-significant bit, matching `Bools()`.
+func FromBools(bools [32]bool) Flag {
+	var flag = New()
+	for i, on := range bools {
+		if on {
+			flag |= 1 << i
+		}
+	}
+	return flag
+}
+
+// # ToMap returns the flag as a map with a `true` entry for each set bit position
+//
+// unset positions are left out to keep the map small, so `m[pos]` is still false for them.
+func (b Flag) ToMap() map[int]bool {
+	var m = make(map[int]bool, b.Count())
+	for pos := range b.Bits() {
+		m[pos] = true
+	}
+	return m
+}
+
+// # FromMap returns a Flag with bit `pos` set for every `true` entry of `m`, the reverse of `ToMap()`
+//
+// returns an error if any key, `true` or `false`, is not a position in the range 0-31.
+func FromMap(m map[int]bool) (Flag, error) {
+	var flag = New()
+	var bad, ok = 0, false
+	for pos, on := range m {
+		switch {
+		case pos < 0 || pos > 31:
+			if !ok || pos < bad {
+				bad, ok = pos, true // report the same key whatever the map order
+			}
+		case on:
+			flag |= 1 << pos
+		}
+	}
+	if ok {
+		return 0, fmt.Errorf("flag: map key %d out of range [0, 32)", bad)
+	}
+	return flag, nil
+}
+
+// # ToBytesBE returns the flag as 4 bytes in big-endian order (most significant byte first)
+func (b Flag) ToBytesBE() [4]byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(b))
+	return buf
+}
+
+// # ToBytesLE returns the flag as 4 bytes in little-endian order (least significant byte first)
+func (b Flag) ToBytesLE() [4]byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(b))
+	return buf
+}
+
+// # FromBytesBE returns the Flag encoded by 4 big-endian bytes, as returned by `ToBytesBE`
+func FromBytesBE(buf [4]byte) Flag {
+	return Flag(binary.BigEndian.Uint32(buf[:]))
+}
+
+// # FromBytesLE returns the Flag encoded by 4 little-endian bytes, as returned by `ToBytesLE`
+func FromBytesLE(buf [4]byte) Flag {
+	return Flag(binary.LittleEndian.Uint32(buf[:]))
+}
@@ -0,0 +1,84 @@
+package flag_test
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestUint32(t *testing.T) {
+	for _, n := range []uint32{0, 42, 0xFFFFFFFF} {
+		if got := flag.NewFromUint(n).AsUint32(); got != n {
+			t.Fatalf("NewFromUint(%#x).AsUint32() = %#x", n, got)
+		}
+	}
+	if flag.NewFromUint(0b101) != flag.NewV(1, 4) {
+		t.Fatal("NewFromUint(0b101) != NewV(1, 4)")
+	}
+}
+
+func TestBools(t *testing.T) {
+	t.Run("index 0 is the LSB", func(t *testing.T) {
+		var bools = flag.Flag(0x80000001).Bools()
+		if !bools[0] || !bools[31] || bools[1] {
+			t.Fatalf("Flag(0x80000001).Bools() = %v", bools)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, 1, 0b101010, 0xDEADBEEF, 0xFFFFFFFF} {
+			if got := flag.FromBools(f.Bools()); got != f {
+				t.Fatalf("FromBools(Flag(%#x).Bools()) = %#x", uint32(f), uint32(got))
+			}
+		}
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Run("only set positions", func(t *testing.T) {
+		if got := flag.Flag(0x80000001).ToMap(); !maps.Equal(got, map[int]bool{0: true, 31: true}) {
+			t.Fatalf("Flag(0x80000001).ToMap() = %v", got)
+		}
+		if got := flag.Flag(0).ToMap(); got == nil || len(got) != 0 {
+			t.Fatalf("Flag(0).ToMap() = %v, want an empty map", got)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, 1, 0b101010, 0xDEADBEEF, 0xFFFFFFFF} {
+			if got, err := flag.FromMap(f.ToMap()); err != nil || got != f {
+				t.Fatalf("FromMap(Flag(%#x).ToMap()) = %#x, %v", uint32(f), uint32(got), err)
+			}
+		}
+	})
+	t.Run("false entries", func(t *testing.T) {
+		if got, err := flag.FromMap(map[int]bool{1: true, 2: false}); err != nil || got != 0b10 {
+			t.Fatalf("FromMap() = %#b, %v, want 0b10, nil", uint32(got), err)
+		}
+	})
+	t.Run("out of range", func(t *testing.T) {
+		for _, m := range []map[int]bool{{32: true}, {-1: false}, {1: true, 40: true, 33: false}} {
+			if _, err := flag.FromMap(m); err == nil {
+				t.Fatalf("FromMap(%v) did not return an error", m)
+			}
+		}
+		if _, err := flag.FromMap(map[int]bool{40: true, 33: false, 99: true}); err == nil || !strings.Contains(err.Error(), "33") {
+			t.Fatalf("FromMap() = %v, want an error naming the lowest bad key 33", err)
+		}
+	})
+}
+
+func TestBytes(t *testing.T) {
+	var f flag.Flag = 0x01020304
+	if be, le := f.ToBytesBE(), f.ToBytesLE(); be != [4]byte{1, 2, 3, 4} || le != [4]byte{4, 3, 2, 1} {
+		t.Fatalf("ToBytesBE() = %v, ToBytesLE() = %v", be, le)
+	}
+	for _, f := range []flag.Flag{0, 1, 0xDEADBEEF, 0xFFFFFFFF} {
+		if got := flag.FromBytesBE(f.ToBytesBE()); got != f {
+			t.Fatalf("FromBytesBE(ToBytesBE(%#x)) = %#x", uint32(f), uint32(got))
+		}
+		if got := flag.FromBytesLE(f.ToBytesLE()); got != f {
+			t.Fatalf("FromBytesLE(ToBytesLE(%#x)) = %#x", uint32(f), uint32(got))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package flag
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// # MarshalJSON encodes the flag as a plain JSON number
+//
+// implements the json.Marshaler interface
+func (b Flag) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(b), 10), nil
+}
+
+// # UnmarshalJSON decodes a JSON number into the flag
+//
+// JSON `null` sets the flag to zero. Numbers that aren't integers in the uint32 range return an error.
+//
+// implements the json.Unmarshaler interface
+func (b *Flag) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*b = 0
+		return nil
+	}
+	n, err := strconv.ParseUint(string(data), 10, 32)
+	if err != nil {
+		return fmt.Errorf("flag: cannot unmarshal JSON %s into Flag: %w", data, err)
+	}
+	*b = Flag(n)
+	return nil
+}
+
+// # MarshalText encodes the flag as hexadecimal text, e.g. "0x2a"
+//
+// implements the encoding.TextMarshaler interface, so flags can be used as JSON map keys.
+func (b Flag) MarshalText() ([]byte, error) {
+	return []byte(b.Hex()), nil
+}
+
+// # UnmarshalText decodes hexadecimal ("0x2a"), binary ("0b101010") or decimal ("42") text into the flag
+//
+// implements the encoding.TextUnmarshaler interface
+func (b *Flag) UnmarshalText(text []byte) error {
+	flag, err := parseNumber("UnmarshalText", string(text))
+	if err != nil {
+		return err
+	}
+	*b = flag
+	return nil
+}
+
+// # MarshalBinary encodes the flag as 4 bytes in big-endian order
+//
+// implements the encoding.BinaryMarshaler interface
+func (b Flag) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint32(make([]byte, 0, 4), uint32(b)), nil
+}
+
+// # UnmarshalBinary decodes 4 big-endian bytes into the flag
+//
+// returns an error if `data` isn't exactly 4 bytes long.
+//
+// implements the encoding.BinaryUnmarshaler interface
+func (b *Flag) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return fmt.Errorf("flag: cannot unmarshal %d bytes into Flag, want 4", len(data))
+	}
+	*b = Flag(binary.BigEndian.Uint32(data))
+	return nil
+}
+
+// # GobEncode encodes the flag as 4 bytes in big-endian order, the same encoding as `MarshalBinary`
+//
+// gob would use `MarshalBinary` on its own, but it prefers GobEncoder when a type has both,
+// so this pins the gob form down even if `MarshalBinary` changes.
+//
+// implements the gob.GobEncoder interface
+func (b Flag) GobEncode() ([]byte, error) {
+	return b.MarshalBinary()
+}
+
+// # GobDecode decodes 4 big-endian bytes into the flag, the same encoding as `UnmarshalBinary`
+//
+// implements the gob.GobDecoder interface
+func (b *Flag) GobDecode(data []byte) error {
+	return b.UnmarshalBinary(data)
+}
+
+// # WriteTo writes the flag to `w` as 4 bytes in big-endian order, the same encoding as `MarshalBinary`
+//
+// implements the io.WriterTo interface
+func (b Flag) WriteTo(w io.Writer) (int64, error) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(b))
+	n, err := w.Write(buf[:])
+	return int64(n), err
+}
+
+// # ReadFrom reads exactly 4 big-endian bytes from `r` into the flag, the same encoding as `UnmarshalBinary`
+//
+// returns io.ErrUnexpectedEOF if `r` ends before 4 bytes are read.
+//
+// implements the io.ReaderFrom interface
+func (b *Flag) ReadFrom(r io.Reader) (int64, error) {
+	var buf [4]byte
+	n, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return int64(n), err
+	}
+	*b = Flag(binary.BigEndian.Uint32(buf[:]))
+	return int64(n), nil
+}
@@ -0,0 +1,149 @@
+package flag_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestJSON(t *testing.T) {
+	type doc struct {
+		Perms flag.Flag `json:"perms"`
+	}
+	t.Run("round trip", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, 42, 0xFFFFFFFF} {
+			data, err := json.Marshal(doc{Perms: f})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got doc
+			if err := json.Unmarshal(data, &got); err != nil || got.Perms != f {
+				t.Fatalf("json.Unmarshal(%s) = %d, %v, want %d", data, uint32(got.Perms), err, uint32(f))
+			}
+		}
+		if data, _ := json.Marshal(doc{Perms: 42}); string(data) != `{"perms":42}` {
+			t.Fatalf("json.Marshal() = %s, want {\"perms\":42}", data)
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		var got = doc{Perms: 42}
+		if err := json.Unmarshal([]byte(`{"perms":null}`), &got); err != nil || got.Perms != 0 {
+			t.Fatalf("json.Unmarshal(null) = %d, %v, want 0", uint32(got.Perms), err)
+		}
+	})
+	t.Run("rejects out of range", func(t *testing.T) {
+		for _, data := range []string{`4294967296`, `-1`, `1.5`, `"42"`} {
+			var f flag.Flag
+			if err := json.Unmarshal([]byte(data), &f); err == nil {
+				t.Fatalf("json.Unmarshal(%s) = %d, want an error", data, uint32(f))
+			}
+		}
+	})
+}
+
+func TestText(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, 42, 0xFFFFFFFF} {
+			text, _ := f.MarshalText()
+			var got flag.Flag
+			if err := got.UnmarshalText(text); err != nil || got != f {
+				t.Fatalf("UnmarshalText(%q) = %d, %v, want %d", text, uint32(got), err, uint32(f))
+			}
+		}
+	})
+	t.Run("accepted formats", func(t *testing.T) {
+		for _, text := range []string{"0x2a", "0X2A", "0b101010", "42"} {
+			var got flag.Flag
+			if err := got.UnmarshalText([]byte(text)); err != nil || got != 42 {
+				t.Fatalf("UnmarshalText(%q) = %d, %v, want 42", text, uint32(got), err)
+			}
+		}
+		var got flag.Flag
+		if err := got.UnmarshalText([]byte("0b2")); err == nil {
+			t.Fatal("UnmarshalText(\"0b2\") did not return an error")
+		}
+	})
+	t.Run("map keys", func(t *testing.T) {
+		data, err := json.Marshal(map[flag.Flag]string{42: "a"})
+		if err != nil || string(data) != `{"0x2a":"a"}` {
+			t.Fatalf("json.Marshal(map) = %s, %v", data, err)
+		}
+		var got map[flag.Flag]string
+		if err := json.Unmarshal(data, &got); err != nil || got[42] != "a" {
+			t.Fatalf("json.Unmarshal(%s) = %v, %v", data, got, err)
+		}
+	})
+}
+
+func TestBinaryMarshal(t *testing.T) {
+	t.Run("big-endian", func(t *testing.T) {
+		data, err := flag.Flag(0x01020304).MarshalBinary()
+		if err != nil || !bytes.Equal(data, []byte{1, 2, 3, 4}) {
+			t.Fatalf("MarshalBinary() = %v, %v, want [1 2 3 4]", data, err)
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, 42, 0xDEADBEEF} {
+			data, _ := f.MarshalBinary()
+			var got flag.Flag
+			if err := got.UnmarshalBinary(data); err != nil || got != f {
+				t.Fatalf("UnmarshalBinary(%v) = %#x, %v, want %#x", data, uint32(got), err, uint32(f))
+			}
+		}
+	})
+	t.Run("wrong length", func(t *testing.T) {
+		for _, data := range [][]byte{nil, {1, 2, 3}, {1, 2, 3, 4, 5}} {
+			var got flag.Flag
+			if err := got.UnmarshalBinary(data); err == nil {
+				t.Fatalf("UnmarshalBinary(%v) did not return an error", data)
+			}
+		}
+	})
+}
+
+func TestGob(t *testing.T) {
+	type file struct {
+		Name string
+		Perm flag.Flag
+		Mask flag.Flag
+	}
+	var buf bytes.Buffer
+	var want = file{Name: "a.txt", Perm: 0xDEADBEEF}
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatal(err)
+	}
+	var got file
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil || got != want {
+		t.Fatalf("gob round trip = %+v, %v, want %+v", got, err, want)
+	}
+	if data, err := flag.Flag(0x01020304).GobEncode(); err != nil || !bytes.Equal(data, []byte{1, 2, 3, 4}) {
+		t.Fatalf("GobEncode() = %v, %v, want [1 2 3 4]", data, err)
+	}
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	var buf bytes.Buffer
+	for _, f := range []flag.Flag{0x01020304, 42} {
+		if n, err := f.WriteTo(&buf); n != 4 || err != nil {
+			t.Fatalf("WriteTo() = %d, %v, want 4, nil", n, err)
+		}
+	}
+	if !bytes.Equal(buf.Bytes()[:4], []byte{1, 2, 3, 4}) {
+		t.Fatalf("WriteTo() wrote %v, want big-endian [1 2 3 4]", buf.Bytes()[:4])
+	}
+	for _, want := range []flag.Flag{0x01020304, 42} {
+		var f flag.Flag
+		if n, err := f.ReadFrom(&buf); n != 4 || err != nil || f != want {
+			t.Fatalf("ReadFrom() = %d, %v, f = %#x, want %#x", n, err, uint32(f), uint32(want))
+		}
+	}
+	var f flag.Flag
+	if _, err := f.ReadFrom(bytes.NewReader([]byte{1, 2})); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("ReadFrom() of 2 bytes: err = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
@@ -5,7 +5,7 @@ Provides variadic forms of functions that end in "V", but these are not as effic
 
 In other words, `func (b *Flag) Clear(flag Flag)` is preferrable to `func (b *Flag) ClearV(flags ...Flag)`.
 
-But, you can use the variadic ones if you want and are okay with a little slice allocation overhead (which isn't much because these are uint32 anyways).
+But, you can use the variadic ones if you want and are okay with the small overhead of looping over the slice of arguments (the slice stays on the stack, so there is no heap allocation).
 
 # Example:
 
@@ -21,14 +21,18 @@ But, you can use the variadic ones if you want and are okay with a little slice
 
 	var f flag.Flag = 0
 	f.Set(FlagB)
-	fmt.Print(f.String()) // prints binary "0010"
+	fmt.Print(f.String()) // prints binary "00000000000000000000000000000010"
 	f.ClearAll() // flag == 0 (no flags set on the "f" Flag variable)
 	f.ToggleV(FlagC, FlagA) // variadic forms of functions end in "V". These have performance overhead though but allow for multiple arguments.
 	f.IsSet(FlagA) // now returns true because of the line above
 */
 package flag
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+)
 
 /*
 `Flag` can store 32 true/false (or on/off) values.
@@ -47,7 +51,7 @@ import "fmt"
 
 	var f flag.Flag = 0
 	f.Set(FlagB)
-	fmt.Print(f.String()) // prints binary "0010"
+	fmt.Print(f.String()) // prints binary "00000000000000000000000000000010"
 	f.ClearAll() // flag == 0 (no flags set on the "f" Flag variable)
 	f.ToggleV(FlagC, FlagA) // variadic forms of functions end in "V". These have performance overhead though but allow for multiple arguments.
 	f.IsSet(FlagA) // now returns true because of the line above
@@ -61,18 +65,31 @@ func New() Flag {
 
 //# NewV returns a Flag with all the provided `flags` set to true/on
 //
-//some heap overhead compared to `New()` because it is variadic and uses a slice of Flag (`uint32`s).
+//a little slower than `New()` plus `Set` because it is variadic and loops over a slice of Flag (`uint32`s) (the slice stays on the stack, so nothing is heap allocated).
 func NewV(flags ...Flag) Flag {
 	var flag = New()
 	flag.SetV(flags...)
 	return flag
 }
 
-//String returns the binary formatted string
+//# Clone returns a copy of the flag
+//
+//Flag is a plain value so copying is free, but Clone makes it explicit that the caller gets a snapshot
+//that doesn't share state with `b`.
+func (b Flag) Clone() Flag {
+	return b
+}
+
+//String returns the names of the set flags registered in `DefaultRegistry`, e.g. "Read|Write".
+//
+//If none of the set bits have a name (or nothing is registered), it returns the binary formatted string, zero-padded to 32 bits
 //
 //implements the fmt.Stringer interface
-func (b *Flag) String() string {
-	return fmt.Sprintf("%b", b)
+func (b Flag) String() string {
+	if s, ok := DefaultRegistry.namedString(b); ok {
+		return s
+	}
+	return fmt.Sprintf("%032b", uint32(b))
 }
 
 //Set sets a given flag to be true/on
@@ -97,6 +114,23 @@ func (b *Flag) SetV(flags ...Flag) *Flag {
 	return b
 }
 
+//# Set2 sets both provided flags to `1` (on/true)
+//
+//Fixed-arity version of `SetV(flags ...Flag)` that doesn't build a slice or loop over it.
+//`BenchmarkSet` in the tests compares the two.
+func (b *Flag) Set2(f1, f2 Flag) *Flag {
+	*b |= f1 | f2
+	return b
+}
+
+//# Set3 sets all three provided flags to `1` (on/true)
+//
+//Fixed-arity version of `SetV(flags ...Flag)` that doesn't build a slice or loop over it.
+func (b *Flag) Set3(f1, f2, f3 Flag) *Flag {
+	*b |= f1 | f2 | f3
+	return b
+}
+
 /*
 # Toggle toggles the provided flag
 
@@ -123,6 +157,14 @@ func (b *Flag) ToggleV(flags ...Flag) *Flag {
 	return b
 }
 
+//# ToggleMasked flips every bit set in `mask`
+//
+//Does the same work as `Toggle(flag Flag)`; the name makes it clear at call sites that `mask` is a multi-bit mask rather than a single flag.
+func (b *Flag) ToggleMasked(mask Flag) *Flag {
+	*b ^= mask
+	return b
+}
+
 //# Clear sets a provided flag to `0` (false/off)
 func (b *Flag) Clear(flag Flag) *Flag {
 	*b &^= flag
@@ -145,6 +187,95 @@ func (b *Flag) ClearV(flags ...Flag) *Flag {
 	return b
 }
 
+//# SetAllExcept sets every bit to `1` except the bits in `mask`, which are set to `0`
+//
+//flag will equal `^mask`
+func (b *Flag) SetAllExcept(mask Flag) *Flag {
+	*b = ^mask
+	return b
+}
+
+//# ClearAllExcept sets every bit to `0` except the bits in `mask`, which keep their value
+func (b *Flag) ClearAllExcept(mask Flag) *Flag {
+	*b &= mask
+	return b
+}
+
+//# KeepOnly clears every bit that isn't in `allowed`
+//
+//Same as `ClearAllExcept(mask Flag)`, named for sanitizing untrusted input before storing it.
+func (b *Flag) KeepOnly(allowed Flag) *Flag {
+	*b &= allowed
+	return b
+}
+
+//# SetTo sets the provided flag when `value` is true and clears it when `value` is false
+func (b *Flag) SetTo(flag Flag, value bool) *Flag {
+	if value {
+		return b.Set(flag)
+	}
+	return b.Clear(flag)
+}
+
+//# Apply calls `SetTo(flag, value)` for every entry of `m`
+//
+//the result doesn't depend on map iteration order as long as the keys don't share bits.
+//If two keys overlap, which one wins for the shared bits is undefined.
+func (b *Flag) Apply(m map[Flag]bool) *Flag {
+	for flag, value := range m {
+		b.SetTo(flag, value)
+	}
+	return b
+}
+
+//# SetIf sets the provided flag only if `cond` is true
+//
+//always returns the receiver, so calls can be chained: `f.SetIf(a, FlagA).SetIf(b, FlagB)`
+func (b *Flag) SetIf(cond bool, flag Flag) *Flag {
+	if cond {
+		*b |= flag
+	}
+	return b
+}
+
+//# ClearIf clears the provided flag only if `cond` is true
+func (b *Flag) ClearIf(cond bool, flag Flag) *Flag {
+	if cond {
+		*b &^= flag
+	}
+	return b
+}
+
+//# ToggleIf toggles the provided flag only if `cond` is true
+func (b *Flag) ToggleIf(cond bool, flag Flag) *Flag {
+	if cond {
+		*b ^= flag
+	}
+	return b
+}
+
+//# SetAndChanged sets the provided flag and returns `true` if at least one bit was newly set
+func (b *Flag) SetAndChanged(flag Flag) bool {
+	old := *b
+	*b |= flag
+	return *b != old
+}
+
+//# ClearAndChanged clears the provided flag and returns `true` if at least one bit was set before
+func (b *Flag) ClearAndChanged(flag Flag) bool {
+	old := *b
+	*b &^= flag
+	return *b != old
+}
+
+//# ToggleAndChanged toggles the provided flag and returns `true` if anything changed
+//
+//toggling always changes the value unless `flag` is zero.
+func (b *Flag) ToggleAndChanged(flag Flag) bool {
+	*b ^= flag
+	return flag != 0
+}
+
 //# Has returns `true` if the provided flag is set
 //
 //Example:
@@ -170,3 +301,122 @@ func (b Flag) HasV(flags ...Flag) bool {
 	}
 	return true
 }
+
+//# Count returns the number of set bits (flags that are true/on)
+func (b Flag) Count() int {
+	return bits.OnesCount32(uint32(b))
+}
+
+//# Density returns the fraction of the 32 bits that are set, from 0.0 (empty) to 1.0 (all set)
+func (b Flag) Density() float64 {
+	return float64(b.Count()) / 32
+}
+
+//# IsEmpty returns `true` if no bits are set
+func (b Flag) IsEmpty() bool {
+	return b == 0
+}
+
+//# IsAll returns `true` if every bit is set
+//
+//equivalent to `b == 0xFFFFFFFF`
+func (b Flag) IsAll() bool {
+	return b == 0xFFFFFFFF
+}
+
+//# HasAny returns `true` if at least one of the provided flags is set.
+//
+//Like `Has(flag Flag)`, a multi-bit flag only counts as set when all of its bits are. A zero flag has no bits to set,
+//so it never counts, and `false` is returned if no flags (or only zero flags) are provided.
+//
+//Returns as soon as a match is found. Opposite of `HasV(flags ...Flag)`, which requires all of them.
+func (b Flag) HasAny(flags ...Flag) bool {
+	for _, flag := range flags {
+		if flag != 0 && b&flag == flag {
+			return true
+		}
+	}
+	return false
+}
+
+//# HasAnyIn returns `true` if any bit of `mask` is set
+//
+//Unlike `HasAny(flags ...Flag)`, a single matching bit is enough, so `Flag(0b101).HasAnyIn(0b110)` is `true`
+//while `Flag(0b101).HasAny(0b110)` is `false`.
+func (b Flag) HasAnyIn(mask Flag) bool {
+	return b&mask != 0
+}
+
+//# Contains returns `true` if the provided flag is set
+//
+//Same as `Has(flag Flag)`.
+func (b Flag) Contains(flag Flag) bool {
+	return b.Has(flag)
+}
+
+//# All returns `true` if all the provided flags are set, and `true` if no flags are provided.
+//
+//Same as `HasV(flags ...Flag)`. A zero flag has no bits that could be unset, so it never makes All return `false`.
+func (b Flag) All(flags ...Flag) bool {
+	return b.HasV(flags...)
+}
+
+//# Any returns `true` if at least one of the provided flags is set, and `false` if no flags are provided.
+//
+//Same as `HasAny(flags ...Flag)`. Like `Has(flag Flag)`, a multi-bit flag only counts as set when all of its bits are,
+//and a zero flag never counts as set, so `Any(0)` is `false`.
+func (b Flag) Any(flags ...Flag) bool {
+	return b.HasAny(flags...)
+}
+
+//# None returns `true` if none of the provided flags are set, and `true` if no flags are provided.
+//
+//Opposite of `Any(flags ...Flag)`, and returns as soon as a set flag is found. A zero flag never counts as set,
+//so `None(0)` is `true`.
+func (b Flag) None(flags ...Flag) bool {
+	return !b.HasAny(flags...)
+}
+
+//# Equal returns `true` if both flags have exactly the same bits set
+func (b Flag) Equal(other Flag) bool {
+	return b == other
+}
+
+//# EqualIgnoring returns `true` if `b` and `other` have the same bits set, not counting the bits in `ignore`
+//
+//Handy for skipping volatile bits like a "dirty" marker without masking both values by hand.
+func (b Flag) EqualIgnoring(other, ignore Flag) bool {
+	return b&^ignore == other&^ignore
+}
+
+//# Compare returns -1 if `b` is less than `other`, 0 if they are equal and 1 if `b` is greater.
+//
+//Flags are ordered by their raw `uint32` value, not by how many bits are set.
+//
+//Can be passed to `slices.SortFunc` as `flag.Flag.Compare`.
+func (b Flag) Compare(other Flag) int {
+	return cmp.Compare(b, other)
+}
+
+//# IsSubsetOf returns `true` if every bit set in `b` is also set in `other`
+//
+//An empty flag is a subset of everything.
+func (b Flag) IsSubsetOf(other Flag) bool {
+	return b&other == b
+}
+
+//# IsSupersetOf returns `true` if every bit set in `other` is also set in `b`
+//
+//Mirror of `IsSubsetOf(other Flag)`.
+func (b Flag) IsSupersetOf(other Flag) bool {
+	return b&other == other
+}
+
+//# Intersects returns `true` if `b` and `other` share at least one set bit
+//
+//Unlike `Has(flag Flag)`, `other` doesn't have to be fully contained in `b`.
+//
+//Same as `HasAnyIn(mask Flag)`, but reads better when comparing two flag values.
+func (b Flag) Intersects(other Flag) bool {
+	return b&other != 0
+}
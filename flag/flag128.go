@@ -0,0 +1,157 @@
+package flag
+
+import "fmt"
+
+/*
+`Flag128` can store 128 true/false (or on/off) values.
+
+It is made of two `uint64` words: bit positions 0-63 live in the low word and 64-127 in the high word.
+Because `1 << 70` doesn't fit in a single integer constant, flags are built with `Bit128(pos)` instead of `1 << iota`.
+
+# Example:
+
+	var (
+		FlagA = flag.Bit128(0)
+		FlagB = flag.Bit128(64)
+		FlagC = flag.Bit128(127)
+	)
+
+	var f = flag.New128()
+	f.Set(FlagB)
+	f.ToggleV(FlagC, FlagA)
+	f.Has(FlagA) // true
+*/
+type Flag128 struct {
+	lo, hi uint64
+}
+
+// # New128 returns a Flag128 variable initialized to zero.
+func New128() Flag128 {
+	return Flag128{}
+}
+
+// # NewV128 returns a Flag128 with all the provided `flags` set to true/on
+//
+// a little slower than `New128()` plus `Set` because it is variadic and loops over a slice of Flag128 (the slice stays on the stack, so nothing is heap allocated).
+func NewV128(flags ...Flag128) Flag128 {
+	var flag = New128()
+	flag.SetV(flags...)
+	return flag
+}
+
+// # New128Words returns a Flag128 built from its high and low words.
+func New128Words(hi, lo uint64) Flag128 {
+	return Flag128{lo: lo, hi: hi}
+}
+
+// # Bit128 returns a Flag128 with only the bit at `pos` set.
+//
+// panics if `pos` is not in the range 0-127.
+func Bit128(pos uint) Flag128 {
+	switch {
+	case pos < 64:
+		return Flag128{lo: 1 << pos}
+	case pos < 128:
+		return Flag128{hi: 1 << (pos - 64)}
+	}
+	panic(fmt.Sprintf("flag: Bit128 position %d out of range [0, 128)", pos))
+}
+
+// # Words returns the high and low words of the flag.
+func (b Flag128) Words() (hi, lo uint64) {
+	return b.hi, b.lo
+}
+
+// String returns the binary formatted string, zero-padded to 128 bits
+//
+// implements the fmt.Stringer interface
+func (b Flag128) String() string {
+	return fmt.Sprintf("%064b%064b", b.hi, b.lo)
+}
+
+// Set sets a given flag to be true/on
+func (b *Flag128) Set(flag Flag128) *Flag128 {
+	b.lo |= flag.lo
+	b.hi |= flag.hi
+	return b
+}
+
+// SetAll sets all bits to `1` (on/true)
+func (b *Flag128) SetAll() *Flag128 {
+	b.lo = 0xFFFFFFFFFFFFFFFF
+	b.hi = 0xFFFFFFFFFFFFFFFF
+	return b
+}
+
+// SetV sets each flag provided to `1` (on/true)
+func (b *Flag128) SetV(flags ...Flag128) *Flag128 {
+	for _, flag := range flags {
+		b.Set(flag)
+	}
+	return b
+}
+
+// # Toggle toggles the provided flag
+//
+// if the provided flag is off, Toggle turns it on, and vice versa.
+func (b *Flag128) Toggle(flag Flag128) *Flag128 {
+	b.lo ^= flag.lo
+	b.hi ^= flag.hi
+	return b
+}
+
+// # ToggleAll toggles every bit/flag
+func (b *Flag128) ToggleAll() *Flag128 {
+	b.lo = ^b.lo
+	b.hi = ^b.hi
+	return b
+}
+
+// # ToggleV toggles each flag provided
+func (b *Flag128) ToggleV(flags ...Flag128) *Flag128 {
+	for _, flag := range flags {
+		b.Toggle(flag)
+	}
+	return b
+}
+
+// # Clear sets a provided flag to `0` (false/off)
+func (b *Flag128) Clear(flag Flag128) *Flag128 {
+	b.lo &^= flag.lo
+	b.hi &^= flag.hi
+	return b
+}
+
+// # ClearAll sets all bits back to `0` (all false/off)
+func (b *Flag128) ClearAll() *Flag128 {
+	b.lo = 0
+	b.hi = 0
+	return b
+}
+
+// # ClearV sets all the provided flags to zero.
+//
+// Variadic version of `Clear(flag Flag128)`.
+func (b *Flag128) ClearV(flags ...Flag128) *Flag128 {
+	for _, flag := range flags {
+		b.Clear(flag)
+	}
+	return b
+}
+
+// # Has returns `true` if the provided flag is set
+func (b Flag128) Has(flag Flag128) bool {
+	return b.lo&flag.lo == flag.lo && b.hi&flag.hi == flag.hi
+}
+
+// # HasV returns `true` if all the provided flags are set.
+//
+// Variadic version of `Has(flag Flag128)`.
+func (b Flag128) HasV(flags ...Flag128) bool {
+	for _, flag := range flags {
+		if !b.Has(flag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package flag_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestFlag128(t *testing.T) {
+	t.Run("Bit128() word boundary", func(t *testing.T) {
+		for pos, want := range map[uint][2]uint64{
+			0:   {0, 1},
+			63:  {0, 1 << 63},
+			64:  {1, 0},
+			127: {1 << 63, 0},
+		} {
+			if hi, lo := flag.Bit128(pos).Words(); hi != want[0] || lo != want[1] {
+				t.Fatalf("Bit128(%d).Words() = (%#x, %#x), want (%#x, %#x)", pos, hi, lo, want[0], want[1])
+			}
+		}
+	})
+	t.Run("Bit128() out of range", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Bit128(128) did not panic")
+			}
+		}()
+		flag.Bit128(128)
+	})
+	t.Run("Set() across words", func(t *testing.T) {
+		var f = flag.New128()
+		f.SetV(flag.Bit128(63), flag.Bit128(64))
+		if !f.Has(flag.Bit128(63)) || !f.Has(flag.Bit128(64)) || f.Has(flag.Bit128(65)) {
+			t.Fatalf("SetV(63, 64) = %s", f)
+		}
+		if f != flag.New128Words(1, 1<<63) {
+			t.Fatalf("SetV(63, 64) = %s", f)
+		}
+	})
+	t.Run("Toggle() and Clear()", func(t *testing.T) {
+		var f = flag.NewV128(flag.Bit128(1), flag.Bit128(100))
+		f.Toggle(flag.Bit128(100)).ToggleV(flag.Bit128(70), flag.Bit128(2)).Clear(flag.Bit128(2))
+		if !f.HasV(flag.Bit128(1), flag.Bit128(70)) || f.Has(flag.Bit128(100)) || f.Has(flag.Bit128(2)) {
+			t.Fatalf("got %s", f)
+		}
+		f.ClearV(flag.Bit128(1), flag.Bit128(70))
+		if f != flag.New128() {
+			t.Fatalf("ClearV() = %s, want zero", f)
+		}
+	})
+	t.Run("SetAll() and ToggleAll()", func(t *testing.T) {
+		var f = flag.New128()
+		f.SetAll()
+		if f != flag.New128Words(0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF) {
+			t.Fatalf("SetAll() = %s", f)
+		}
+		f.Clear(flag.Bit128(64)).ToggleAll()
+		if f != flag.Bit128(64) {
+			t.Fatalf("ToggleAll() = %s, want only bit 64", f)
+		}
+		if f.ClearAll(); f != flag.New128() {
+			t.Fatalf("ClearAll() = %s, want zero", f)
+		}
+	})
+	t.Run("String()", func(t *testing.T) {
+		var f = flag.NewV128(flag.Bit128(0), flag.Bit128(64))
+		want := strings.Repeat("0", 63) + "1" + strings.Repeat("0", 63) + "1"
+		if got := f.String(); got != want {
+			t.Fatalf("String() = %q, want %q", got, want)
+		}
+	})
+}
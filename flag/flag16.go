@@ -0,0 +1,127 @@
+package flag
+
+import "fmt"
+
+/*
+`Flag16` can store 16 true/false (or on/off) values.
+
+It mirrors the `Flag` API for when you only need 16 bits and want to save memory.
+
+# Example:
+
+	const (
+		FlagA flag.Flag16 = 1 << iota // 0001
+		FlagB                         // 0010
+		FlagC                         // 0100
+	)
+
+	var f = flag.New16()
+	f.Set(FlagB)
+	f.ToggleV(FlagC, FlagA)
+	f.Has(FlagA) // true
+*/
+type Flag16 uint16
+
+// # New16 returns a Flag16 variable initialized to zero.
+func New16() Flag16 {
+	return Flag16(0)
+}
+
+// # NewV16 returns a Flag16 with all the provided `flags` set to true/on
+//
+// a little slower than `New16()` plus `Set` because it is variadic and loops over a slice of Flag16 (`uint16`s) (the slice stays on the stack, so nothing is heap allocated).
+func NewV16(flags ...Flag16) Flag16 {
+	var flag = New16()
+	flag.SetV(flags...)
+	return flag
+}
+
+// String returns the binary formatted string, zero-padded to 16 bits
+//
+// implements the fmt.Stringer interface
+func (b Flag16) String() string {
+	return fmt.Sprintf("%016b", uint16(b))
+}
+
+// Set sets a given flag to be true/on
+func (b *Flag16) Set(flag Flag16) *Flag16 {
+	*b |= flag
+	return b
+}
+
+// SetAll sets all bits to `1` (on/true)
+//
+// flag will equal `0xFFFF` (max value of uint16)
+func (b *Flag16) SetAll() *Flag16 {
+	*b = 0xFFFF
+	return b
+}
+
+// SetV sets each flag provided to `1` (on/true)
+func (b *Flag16) SetV(flags ...Flag16) *Flag16 {
+	for _, flag := range flags {
+		*b |= flag
+	}
+	return b
+}
+
+// # Toggle toggles the provided flag
+//
+// if the provided flag is off, Toggle turns it on, and vice versa.
+func (b *Flag16) Toggle(flag Flag16) *Flag16 {
+	*b ^= flag
+	return b
+}
+
+// # ToggleAll toggles every bit/flag
+func (b *Flag16) ToggleAll() *Flag16 {
+	*b = ^*b
+	return b
+}
+
+// # ToggleV toggles each flag provided
+func (b *Flag16) ToggleV(flags ...Flag16) *Flag16 {
+	for _, flag := range flags {
+		*b ^= flag
+	}
+	return b
+}
+
+// # Clear sets a provided flag to `0` (false/off)
+func (b *Flag16) Clear(flag Flag16) *Flag16 {
+	*b &^= flag
+	return b
+}
+
+// # ClearAll sets all bits back to `0` (all false/off)
+func (b *Flag16) ClearAll() *Flag16 {
+	*b = 0
+	return b
+}
+
+// # ClearV sets all the provided flags to zero.
+//
+// Variadic version of `Clear(flag Flag16)`.
+func (b *Flag16) ClearV(flags ...Flag16) *Flag16 {
+	for _, flag := range flags {
+		*b &^= flag
+	}
+	return b
+}
+
+// # Has returns `true` if the provided flag is set
+func (b Flag16) Has(flag Flag16) bool {
+	return b&flag == flag
+}
+
+// # HasV returns `true` if all the provided flags are set.
+//
+// Variadic version of `Has(flag Flag16)`.
+func (b Flag16) HasV(flags ...Flag16) bool {
+	for _, flag := range flags {
+		if b&flag != flag {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestFlag16(t *testing.T) {
+	t.Run("SetAll()", func(t *testing.T) {
+		var f = flag.New16()
+		if f.SetAll(); f != 0xFFFF {
+			t.Fatalf("SetAll() = %#x, want 0xFFFF", uint16(f))
+		}
+	})
+	t.Run("String() pads to 16 bits", func(t *testing.T) {
+		var f = flag.NewV16(1<<1, 1<<15)
+		if got := f.String(); got != "1000000000000010" {
+			t.Fatalf("String() = %q, want %q", got, "1000000000000010")
+		}
+	})
+	t.Run("no overflow bits", func(t *testing.T) {
+		var f = flag.New16()
+		f.ToggleAll()
+		if f != 0xFFFF || len(f.String()) != 16 {
+			t.Fatalf("ToggleAll() on zero = %q, want 16 ones", f.String())
+		}
+	})
+	t.Run("Toggle()", func(t *testing.T) {
+		var f = flag.New16()
+		f.ToggleV(1<<12, 1).Toggle(1)
+		if f != 1<<12 || !f.Has(1<<12) {
+			t.Fatalf("ToggleV(1<<12, 1).Toggle(1) = %q", f.String())
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package flag
+
+import "fmt"
+
+/*
+`Flag64` can store 64 true/false (or on/off) values.
+
+It mirrors the `Flag` API for when 32 bits aren't enough.
+
+# Example:
+
+	const (
+		FlagA flag.Flag64 = 1 << iota // 0001
+		FlagB                         // 0010
+		FlagC                         // 0100
+	)
+
+	var f = flag.New64()
+	f.Set(FlagB)
+	f.ToggleV(FlagC, FlagA)
+	f.Has(FlagA) // true
+*/
+type Flag64 uint64
+
+// # New64 returns a Flag64 variable initialized to zero.
+func New64() Flag64 {
+	return Flag64(0)
+}
+
+// # NewV64 returns a Flag64 with all the provided `flags` set to true/on
+//
+// a little slower than `New64()` plus `Set` because it is variadic and loops over a slice of Flag64 (`uint64`s) (the slice stays on the stack, so nothing is heap allocated).
+func NewV64(flags ...Flag64) Flag64 {
+	var flag = New64()
+	flag.SetV(flags...)
+	return flag
+}
+
+// String returns the binary formatted string, zero-padded to 64 bits
+//
+// implements the fmt.Stringer interface
+func (b Flag64) String() string {
+	return fmt.Sprintf("%064b", uint64(b))
+}
+
+// Set sets a given flag to be true/on
+func (b *Flag64) Set(flag Flag64) *Flag64 {
+	*b |= flag
+	return b
+}
+
+// SetAll sets all bits to `1` (on/true)
+//
+// flag will equal `0xFFFFFFFFFFFFFFFF` (max value of uint64)
+func (b *Flag64) SetAll() *Flag64 {
+	*b = 0xFFFFFFFFFFFFFFFF
+	return b
+}
+
+// SetV sets each flag provided to `1` (on/true)
+func (b *Flag64) SetV(flags ...Flag64) *Flag64 {
+	for _, flag := range flags {
+		*b |= flag
+	}
+	return b
+}
+
+// # Toggle toggles the provided flag
+//
+// if the provided flag is off, Toggle turns it on, and vice versa.
+func (b *Flag64) Toggle(flag Flag64) *Flag64 {
+	*b ^= flag
+	return b
+}
+
+// # ToggleAll toggles every bit/flag
+func (b *Flag64) ToggleAll() *Flag64 {
+	*b = ^*b
+	return b
+}
+
+// # ToggleV toggles each flag provided
+func (b *Flag64) ToggleV(flags ...Flag64) *Flag64 {
+	for _, flag := range flags {
+		*b ^= flag
+	}
+	return b
+}
+
+// # Clear sets a provided flag to `0` (false/off)
+func (b *Flag64) Clear(flag Flag64) *Flag64 {
+	*b &^= flag
+	return b
+}
+
+// # ClearAll sets all bits back to `0` (all false/off)
+func (b *Flag64) ClearAll() *Flag64 {
+	*b = 0
+	return b
+}
+
+// # ClearV sets all the provided flags to zero.
+//
+// Variadic version of `Clear(flag Flag64)`.
+func (b *Flag64) ClearV(flags ...Flag64) *Flag64 {
+	for _, flag := range flags {
+		*b &^= flag
+	}
+	return b
+}
+
+// # Has returns `true` if the provided flag is set
+func (b Flag64) Has(flag Flag64) bool {
+	return b&flag == flag
+}
+
+// # HasV returns `true` if all the provided flags are set.
+//
+// Variadic version of `Has(flag Flag64)`.
+func (b Flag64) HasV(flags ...Flag64) bool {
+	for _, flag := range flags {
+		if b&flag != flag {
+			return false
+		}
+	}
+	return true
+}
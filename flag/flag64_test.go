@@ -0,0 +1,64 @@
+package flag_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+const (
+	Flag64A flag.Flag64 = 1 << iota
+	Flag64B
+	Flag64C
+)
+
+const Flag64Top flag.Flag64 = 1 << 63
+
+func TestFlag64(t *testing.T) {
+	t.Run("Set()", func(t *testing.T) {
+		var f = flag.New64()
+		f.Set(42)
+		if f != 0b101010 {
+			t.Fatal("var f = flag.New64(), f.Set(42), f != 0b101010")
+		}
+	})
+	t.Run("SetAll()", func(t *testing.T) {
+		var f = flag.New64()
+		if f.SetAll(); f != 0xFFFFFFFFFFFFFFFF {
+			t.Fatalf("SetAll() = %#x, want 0xFFFFFFFFFFFFFFFF", uint64(f))
+		}
+	})
+	t.Run("NewV() and bit 63", func(t *testing.T) {
+		var f = flag.NewV64(Flag64A, Flag64Top)
+		if !f.HasV(Flag64A, Flag64Top) || f.Has(Flag64B) {
+			t.Fatalf("NewV64(Flag64A, Flag64Top) = %#x", uint64(f))
+		}
+		f.Clear(Flag64Top)
+		if f != Flag64A {
+			t.Fatalf("Clear(Flag64Top) = %#x, want %#x", uint64(f), uint64(Flag64A))
+		}
+	})
+	t.Run("Toggle()", func(t *testing.T) {
+		var f = flag.New64()
+		f.ToggleV(Flag64C, Flag64A).Toggle(Flag64A)
+		if f != Flag64C {
+			t.Fatalf("ToggleV(C, A).Toggle(A) = %#x, want %#x", uint64(f), uint64(Flag64C))
+		}
+		f.ToggleAll()
+		if f.Has(Flag64C) || !f.HasV(Flag64A, Flag64B, Flag64Top) {
+			t.Fatalf("ToggleAll() = %#x", uint64(f))
+		}
+		f.ClearV(Flag64A, Flag64B).ClearAll()
+		if f != 0 {
+			t.Fatalf("ClearAll() = %#x, want 0", uint64(f))
+		}
+	})
+	t.Run("String()", func(t *testing.T) {
+		var f = flag.NewV64(Flag64B, Flag64Top)
+		want := "1" + strings.Repeat("0", 61) + "10"
+		if got := f.String(); got != want {
+			t.Fatalf("String() = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package flag
+
+import "fmt"
+
+/*
+`Flag8` can store 8 true/false (or on/off) values.
+
+It mirrors the `Flag` API for when you only need 8 bits and want to save memory.
+
+# Example:
+
+	const (
+		FlagA flag.Flag8 = 1 << iota // 0001
+		FlagB                        // 0010
+		FlagC                        // 0100
+	)
+
+	var f = flag.New8()
+	f.Set(FlagB)
+	f.ToggleV(FlagC, FlagA)
+	f.Has(FlagA) // true
+*/
+type Flag8 uint8
+
+// # New8 returns a Flag8 variable initialized to zero.
+func New8() Flag8 {
+	return Flag8(0)
+}
+
+// # NewV8 returns a Flag8 with all the provided `flags` set to true/on
+//
+// a little slower than `New8()` plus `Set` because it is variadic and loops over a slice of Flag8 (`uint8`s) (the slice stays on the stack, so nothing is heap allocated).
+func NewV8(flags ...Flag8) Flag8 {
+	var flag = New8()
+	flag.SetV(flags...)
+	return flag
+}
+
+// String returns the binary formatted string, zero-padded to 8 bits
+//
+// implements the fmt.Stringer interface
+func (b Flag8) String() string {
+	return fmt.Sprintf("%08b", uint8(b))
+}
+
+// Set sets a given flag to be true/on
+func (b *Flag8) Set(flag Flag8) *Flag8 {
+	*b |= flag
+	return b
+}
+
+// SetAll sets all bits to `1` (on/true)
+//
+// flag will equal `0xFF` (max value of uint8)
+func (b *Flag8) SetAll() *Flag8 {
+	*b = 0xFF
+	return b
+}
+
+// SetV sets each flag provided to `1` (on/true)
+func (b *Flag8) SetV(flags ...Flag8) *Flag8 {
+	for _, flag := range flags {
+		*b |= flag
+	}
+	return b
+}
+
+// # Toggle toggles the provided flag
+//
+// if the provided flag is off, Toggle turns it on, and vice versa.
+func (b *Flag8) Toggle(flag Flag8) *Flag8 {
+	*b ^= flag
+	return b
+}
+
+// # ToggleAll toggles every bit/flag
+func (b *Flag8) ToggleAll() *Flag8 {
+	*b = ^*b
+	return b
+}
+
+// # ToggleV toggles each flag provided
+func (b *Flag8) ToggleV(flags ...Flag8) *Flag8 {
+	for _, flag := range flags {
+		*b ^= flag
+	}
+	return b
+}
+
+// # Clear sets a provided flag to `0` (false/off)
+func (b *Flag8) Clear(flag Flag8) *Flag8 {
+	*b &^= flag
+	return b
+}
+
+// # ClearAll sets all bits back to `0` (all false/off)
+func (b *Flag8) ClearAll() *Flag8 {
+	*b = 0
+	return b
+}
+
+// # ClearV sets all the provided flags to zero.
+//
+// Variadic version of `Clear(flag Flag8)`.
+func (b *Flag8) ClearV(flags ...Flag8) *Flag8 {
+	for _, flag := range flags {
+		*b &^= flag
+	}
+	return b
+}
+
+// # Has returns `true` if the provided flag is set
+func (b Flag8) Has(flag Flag8) bool {
+	return b&flag == flag
+}
+
+// # HasV returns `true` if all the provided flags are set.
+//
+// Variadic version of `Has(flag Flag8)`.
+func (b Flag8) HasV(flags ...Flag8) bool {
+	for _, flag := range flags {
+		if b&flag != flag {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestFlag8(t *testing.T) {
+	t.Run("SetAll()", func(t *testing.T) {
+		var f = flag.New8()
+		if f.SetAll(); f != 0xFF {
+			t.Fatalf("SetAll() = %#x, want 0xFF", uint8(f))
+		}
+	})
+	t.Run("String() pads to 8 bits", func(t *testing.T) {
+		var f = flag.NewV8(1<<1, 1<<3)
+		if got := f.String(); got != "00001010" {
+			t.Fatalf("String() = %q, want %q", got, "00001010")
+		}
+		f.SetAll()
+		if got := f.String(); got != "11111111" {
+			t.Fatalf("SetAll().String() = %q, want %q", got, "11111111")
+		}
+	})
+	t.Run("no overflow bits", func(t *testing.T) {
+		var f = flag.New8()
+		f.Set(1 << 7).ToggleAll().ToggleAll()
+		if f != 1<<7 || len(f.String()) != 8 {
+			t.Fatalf("ToggleAll() twice = %q, want only bit 7", f.String())
+		}
+		f.ClearAll().ToggleAll()
+		if f != 0xFF {
+			t.Fatalf("ToggleAll() on zero = %#x, want 0xFF", uint8(f))
+		}
+	})
+	t.Run("Has()", func(t *testing.T) {
+		var f = flag.New8()
+		f.SetV(1, 4).ClearV(4)
+		if !f.Has(1) || f.HasV(1, 4) {
+			t.Fatalf("SetV(1, 4).ClearV(4) = %q", f.String())
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package flag_test
 
 import (
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/chasecarlson1/go-bitflags/flag"
@@ -17,4 +19,371 @@ func TestFuncs(t *testing.T) {
 			t.Fatal("var f = flag.New(), f.Set(42), f != 0b101010")
 		}
 	})
+	t.Run("Set2() and Set3()", func(t *testing.T) {
+		var f = flag.New()
+		if f.Set2(1, 4).Set3(8, 16, 1); f != flag.NewV(1, 4, 8, 16) {
+			t.Fatalf("Set2(1, 4).Set3(8, 16, 1) = %#b, want 0b11101", uint32(f))
+		}
+	})
+	t.Run("Clone()", func(t *testing.T) {
+		var f = flag.NewV(1)
+		var p = &f
+		var c = p.Clone()
+		c.Set(2)
+		if f != 1 || c != 3 {
+			t.Fatalf("after Clone().Set(2): original = %#b, clone = %#b", uint32(f), uint32(c))
+		}
+	})
+	t.Run("AllExcept()", func(t *testing.T) {
+		var f = flag.New()
+		if f.SetAllExcept(0b0101); f != 0xFFFFFFFA {
+			t.Fatalf("SetAllExcept(0b101) = %#x, want 0xfffffffa", uint32(f))
+		}
+		if f.ClearAllExcept(0b0110).Set(1); f != 0b0011 {
+			t.Fatalf("ClearAllExcept(0b110).Set(1) = %#b, want 0b11", uint32(f))
+		}
+	})
+	t.Run("KeepOnly()", func(t *testing.T) {
+		var f flag.Flag = 1<<31 | 0b1010
+		if f.KeepOnly(0b1111); f != 0b1010 {
+			t.Fatalf("KeepOnly(0b1111) = %#x, want 0b1010", uint32(f))
+		}
+	})
+	t.Run("SetTo()", func(t *testing.T) {
+		var f = flag.NewV(1)
+		f.SetTo(4, true).SetTo(1, false)
+		if f != 4 {
+			t.Fatalf("SetTo(4, true).SetTo(1, false) = %#b, want 0b100", uint32(f))
+		}
+		for i := 0; i < 4; i++ {
+			on := i%2 == 0
+			if f.SetTo(2, on); f.Has(2) != on || !f.Has(4) {
+				t.Fatalf("SetTo(2, %t) = %#b", on, uint32(f))
+			}
+		}
+	})
+	t.Run("Apply()", func(t *testing.T) {
+		var f = flag.NewV(1, 2)
+		f.Apply(map[flag.Flag]bool{1: false, 4: true, 8: false, 1 << 31: true})
+		if f != 1<<31|0b110 {
+			t.Fatalf("Apply() = %#x, want 0x80000006", uint32(f))
+		}
+	})
+	t.Run("SetIf()", func(t *testing.T) {
+		var f = flag.New()
+		f.SetIf(true, 1).SetIf(false, 2).SetIf(true, 4)
+		if f != 0b101 {
+			t.Fatalf("SetIf(true, 1).SetIf(false, 2).SetIf(true, 4) = %#b, want 0b101", uint32(f))
+		}
+		f.ClearIf(false, 1).ClearIf(true, 4).ToggleIf(false, 8).ToggleIf(true, 2)
+		if f != 0b011 {
+			t.Fatalf("ClearIf(false, 1).ClearIf(true, 4).ToggleIf(false, 8).ToggleIf(true, 2) = %#b, want 0b11", uint32(f))
+		}
+	})
+	t.Run("ToggleMasked()", func(t *testing.T) {
+		var f = flag.NewV(1, 1<<20)
+		f.ToggleMasked(0xF0F0)
+		if f != 1|1<<20|0xF0F0 {
+			t.Fatalf("ToggleMasked(0xf0f0) = %#x, want 0x10f0f1", uint32(f))
+		}
+		if f.ToggleMasked(0xF0F0); f != 1|1<<20 {
+			t.Fatalf("ToggleMasked(0xf0f0) twice = %#x, want 0x100001", uint32(f))
+		}
+	})
+	t.Run("AndChanged()", func(t *testing.T) {
+		var f = flag.NewV(1)
+		if f.SetAndChanged(1) {
+			t.Fatal("SetAndChanged() of an already set flag = true, want false")
+		}
+		if !f.SetAndChanged(3) || f != 3 {
+			t.Fatalf("SetAndChanged(3) = false or f = %#b, want true and 0b11", uint32(f))
+		}
+		if f.ClearAndChanged(4) {
+			t.Fatal("ClearAndChanged() of an unset flag = true, want false")
+		}
+		if !f.ClearAndChanged(6) || f != 1 {
+			t.Fatalf("ClearAndChanged(6) = false or f = %#b, want true and 0b1", uint32(f))
+		}
+		if f.ToggleAndChanged(0) || !f.ToggleAndChanged(1) || f != 0 {
+			t.Fatalf("ToggleAndChanged() reported the wrong change, f = %#b", uint32(f))
+		}
+	})
+}
+
+func TestCount(t *testing.T) {
+	var all = flag.New()
+	all.SetAll()
+	for f, want := range map[flag.Flag]int{
+		0:          0,
+		1 << 5:     1,
+		all:        32,
+		0b101010:   3,
+		0xF000000F: 8,
+	} {
+		if got := f.Count(); got != want {
+			t.Fatalf("Flag(%#x).Count() = %d, want %d", uint32(f), got, want)
+		}
+	}
+}
+
+func TestDensity(t *testing.T) {
+	for f, want := range map[flag.Flag]float64{
+		0:          0,
+		1 << 7:     1.0 / 32,
+		0xFFFF:     0.5,
+		0xFFFFFFFF: 1,
+	} {
+		if got := f.Density(); got != want {
+			t.Fatalf("Flag(%#x).Density() = %v, want %v", uint32(f), got, want)
+		}
+	}
+}
+
+func TestIsEmptyIsAll(t *testing.T) {
+	for f, want := range map[flag.Flag][2]bool{
+		0:          {true, false},
+		0xFFFFFFFF: {false, true},
+		0b101010:   {false, false},
+		0x7FFFFFFF: {false, false},
+	} {
+		if f.IsEmpty() != want[0] || f.IsAll() != want[1] {
+			t.Fatalf("Flag(%#x): IsEmpty() = %t, IsAll() = %t, want %t, %t", uint32(f), f.IsEmpty(), f.IsAll(), want[0], want[1])
+		}
+	}
+}
+
+func TestHasAny(t *testing.T) {
+	var f = flag.NewV(1, 4)
+	t.Run("HasAny()", func(t *testing.T) {
+		if f.HasAny() {
+			t.Fatal("HasAny() with no args = true, want false")
+		}
+		if !f.HasAny(2, 4) {
+			t.Fatal("HasAny(2, 4) = false, want true")
+		}
+		if f.HasAny(2, 8) {
+			t.Fatal("HasAny(2, 8) = true, want false")
+		}
+		if f.HasAny(0) || f.HasAny(2, 0) || flag.New().HasAny(0) {
+			t.Fatal("HasAny() counted a zero flag as set")
+		}
+		if f.HasAny(0b110) {
+			t.Fatal("HasAny(0b110) = true, want false (bit 1 is not set)")
+		}
+	})
+	t.Run("HasAnyIn()", func(t *testing.T) {
+		if !f.HasAnyIn(0b1110) {
+			t.Fatal("HasAnyIn(0b1110) = false, want true (overlaps in bit 2)")
+		}
+		if f.HasAnyIn(0b1010) || f.HasAnyIn(0) {
+			t.Fatal("HasAnyIn() of a disjoint mask = true, want false")
+		}
+	})
+}
+
+func TestAnyAllNone(t *testing.T) {
+	var f = flag.NewV(1, 4)
+	for _, c := range []struct {
+		flags          []flag.Flag
+		all, any, none bool
+	}{
+		{nil, true, false, true},
+		{[]flag.Flag{1, 4}, true, true, false},      // all set
+		{[]flag.Flag{1, 0b110}, false, true, false}, // overlapping, 0b110 only partly set
+		{[]flag.Flag{2, 8}, false, false, true},     // disjoint
+		{[]flag.Flag{0}, true, false, true},         // a zero flag is never set
+		{[]flag.Flag{2, 0}, false, false, true},
+		{[]flag.Flag{1, 0}, true, true, false},
+	} {
+		if got := f.All(c.flags...); got != c.all {
+			t.Fatalf("All(%v) = %t, want %t", c.flags, got, c.all)
+		}
+		if got := f.Any(c.flags...); got != c.any {
+			t.Fatalf("Any(%v) = %t, want %t", c.flags, got, c.any)
+		}
+		if got := f.None(c.flags...); got != c.none {
+			t.Fatalf("None(%v) = %t, want %t", c.flags, got, c.none)
+		}
+	}
+	if !f.Contains(4) || !f.Contains(5) || f.Contains(6) {
+		t.Fatal("Contains() disagrees with Has()")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, c := range []struct {
+		a, b flag.Flag
+		want int
+	}{
+		{0b101, 0b101, 0},
+		{0b011, 0b100, -1}, // more bits set but a smaller value
+		{1 << 31, 0x7FFFFFFF, 1},
+	} {
+		if got := c.a.Compare(c.b); got != c.want {
+			t.Fatalf("Flag(%#x).Compare(%#x) = %d, want %d", uint32(c.a), uint32(c.b), got, c.want)
+		}
+		if c.a.Equal(c.b) != (c.want == 0) {
+			t.Fatalf("Flag(%#x).Equal(%#x) = %t", uint32(c.a), uint32(c.b), c.a.Equal(c.b))
+		}
+	}
+	var fs = []flag.Flag{4, 1, 3}
+	slices.SortFunc(fs, flag.Flag.Compare)
+	if !slices.Equal(fs, []flag.Flag{1, 3, 4}) {
+		t.Fatalf("slices.SortFunc(fs, flag.Flag.Compare) = %v", fs)
+	}
+}
+
+func TestEqualIgnoring(t *testing.T) {
+	const dirty flag.Flag = 1 << 31
+	for _, c := range []struct {
+		a, b, ignore flag.Flag
+		want         bool
+	}{
+		{0b101, 0b101, 0, true},
+		{0b101 | dirty, 0b101, dirty, true},
+		{0b101 | dirty, 0b100, dirty, false},
+		{0b101, 0b011, 0b110, true},
+		{0b101, 0b011, 0, false},
+	} {
+		if got := c.a.EqualIgnoring(c.b, c.ignore); got != c.want {
+			t.Fatalf("Flag(%#x).EqualIgnoring(%#x, %#x) = %t, want %t", uint32(c.a), uint32(c.b), uint32(c.ignore), got, c.want)
+		}
+	}
+}
+
+func TestSubset(t *testing.T) {
+	var all = flag.New()
+	all.SetAll()
+	for _, c := range []struct {
+		a, b flag.Flag
+		want bool
+	}{
+		{0, 0b1010, true},
+		{0, 0, true},
+		{0b1010, 0b1010, true},
+		{0b1010, all, true},
+		{0b0010, 0b1010, true},
+		{0b0101, 0b1010, false},
+		{0b1110, 0b1010, false},
+	} {
+		if got := c.a.IsSubsetOf(c.b); got != c.want {
+			t.Fatalf("Flag(%#b).IsSubsetOf(%#b) = %t, want %t", uint32(c.a), uint32(c.b), got, c.want)
+		}
+		if got := c.b.IsSupersetOf(c.a); got != c.want {
+			t.Fatalf("Flag(%#b).IsSupersetOf(%#b) = %t, want %t", uint32(c.b), uint32(c.a), got, c.want)
+		}
+	}
+}
+
+func TestIntersects(t *testing.T) {
+	for _, c := range []struct {
+		a, b flag.Flag
+		want bool
+	}{
+		{0b0101, 0b1010, false},
+		{0b1010, 0b1010, true},
+		{0b0110, 0b1010, true},
+		{0, 0, false},
+	} {
+		if got := c.a.Intersects(c.b); got != c.want {
+			t.Fatalf("Flag(%#b).Intersects(%#b) = %t, want %t", uint32(c.a), uint32(c.b), got, c.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	const (
+		FlagA flag.Flag = 1 << iota
+		FlagB
+	)
+	if got := FlagB.String(); got != "00000000000000000000000000000010" {
+		t.Fatalf("FlagB.String() = %q, want %q", got, "00000000000000000000000000000010")
+	}
+	var f = flag.NewV(FlagA, 1<<31)
+	if got := fmt.Sprint(f); got != "10000000000000000000000000000001" {
+		t.Fatalf("fmt.Sprint(f) = %q, want %q", got, "10000000000000000000000000000001")
+	}
+	if got := fmt.Sprint(&f); got != "10000000000000000000000000000001" {
+		t.Fatalf("fmt.Sprint(&f) = %q, want %q", got, "10000000000000000000000000000001")
+	}
+}
+
+// sink keeps benchmark results alive so the compiler can't drop the work.
+var sink flag.Flag
+
+// BenchmarkSet compares the variadic SetV against the fixed-arity setters.
+// Each case is written out so `f` stays on the stack, like it would at a real call site.
+func BenchmarkSet(b *testing.B) {
+	b.Run("SetV/1", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.SetV(1)
+			sink = f
+		}
+	})
+	b.Run("SetV/2", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.SetV(1, 2)
+			sink = f
+		}
+	})
+	b.Run("SetV/4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.SetV(1, 2, 4, 8)
+			sink = f
+		}
+	})
+	b.Run("SetV/8", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.SetV(1, 2, 4, 8, 16, 32, 64, 128)
+			sink = f
+		}
+	})
+	b.Run("Set/1", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.Set(1)
+			sink = f
+		}
+	})
+	b.Run("Set2/2", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.Set2(1, 2)
+			sink = f
+		}
+	})
+	b.Run("Set2/4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.Set2(1, 2).Set2(4, 8)
+			sink = f
+		}
+	})
+	b.Run("Set3/3", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.Set3(1, 2, 4)
+			sink = f
+		}
+	})
+	b.Run("Set3/8", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var f = flag.New()
+			f.Set3(1, 2, 4).Set3(8, 16, 32).Set2(64, 128)
+			sink = f
+		}
+	})
 }
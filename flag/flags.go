@@ -0,0 +1,140 @@
+package flag
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// unsigned is the set of integer types a `Flags` can be backed by.
+type unsigned interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+/*
+`Flags` is a generic bit flag set backed by any unsigned integer type `T`.
+
+It stores as many true/false values as `T` has bits, so `Flags[uint8]` stores 8 and `Flags[uint64]` stores 64.
+
+# Example:
+
+	const (
+		FlagA uint16 = 1 << iota // 0001
+		FlagB                    // 0010
+		FlagC                    // 0100
+	)
+
+	var f = flag.NewFlags[uint16]()
+	f.SetV(FlagA, FlagC)
+	f.Has(FlagC) // true
+	f.String()   // "0000000000000101"
+*/
+type Flags[T unsigned] struct {
+	bits T
+}
+
+// # NewFlags returns a Flags variable with all the provided `flags` set to true/on
+func NewFlags[T unsigned](flags ...T) Flags[T] {
+	var f Flags[T]
+	f.SetV(flags...)
+	return f
+}
+
+// # Value returns the underlying integer value
+func (b Flags[T]) Value() T {
+	return b.bits
+}
+
+// # Width returns the number of bits `T` can store
+func (b Flags[T]) Width() int {
+	return bits.Len64(uint64(^T(0)))
+}
+
+// String returns the binary formatted string, zero-padded to the bit width of `T`
+//
+// implements the fmt.Stringer interface
+func (b Flags[T]) String() string {
+	return fmt.Sprintf("%0*b", b.Width(), uint64(b.bits))
+}
+
+// Set sets a given flag to be true/on
+func (b *Flags[T]) Set(flag T) *Flags[T] {
+	b.bits |= flag
+	return b
+}
+
+// SetAll sets all bits to `1` (on/true)
+//
+// flag will equal `^T(0)` (max value of `T`)
+func (b *Flags[T]) SetAll() *Flags[T] {
+	b.bits = ^T(0)
+	return b
+}
+
+// SetV sets each flag provided to `1` (on/true)
+func (b *Flags[T]) SetV(flags ...T) *Flags[T] {
+	for _, flag := range flags {
+		b.bits |= flag
+	}
+	return b
+}
+
+// # Toggle toggles the provided flag
+//
+// if the provided flag is off, Toggle turns it on, and vice versa.
+func (b *Flags[T]) Toggle(flag T) *Flags[T] {
+	b.bits ^= flag
+	return b
+}
+
+// # ToggleAll toggles every bit/flag
+func (b *Flags[T]) ToggleAll() *Flags[T] {
+	b.bits = ^b.bits
+	return b
+}
+
+// # ToggleV toggles each flag provided
+func (b *Flags[T]) ToggleV(flags ...T) *Flags[T] {
+	for _, flag := range flags {
+		b.bits ^= flag
+	}
+	return b
+}
+
+// # Clear sets a provided flag to `0` (false/off)
+func (b *Flags[T]) Clear(flag T) *Flags[T] {
+	b.bits &^= flag
+	return b
+}
+
+// # ClearAll sets all bits back to `0` (all false/off)
+func (b *Flags[T]) ClearAll() *Flags[T] {
+	b.bits = 0
+	return b
+}
+
+// # ClearV sets all the provided flags to zero.
+//
+// Variadic version of `Clear(flag T)`.
+func (b *Flags[T]) ClearV(flags ...T) *Flags[T] {
+	for _, flag := range flags {
+		b.bits &^= flag
+	}
+	return b
+}
+
+// # Has returns `true` if the provided flag is set
+func (b Flags[T]) Has(flag T) bool {
+	return b.bits&flag == flag
+}
+
+// # HasV returns `true` if all the provided flags are set.
+//
+// Variadic version of `Has(flag T)`.
+func (b Flags[T]) HasV(flags ...T) bool {
+	for _, flag := range flags {
+		if b.bits&flag != flag {
+			return false
+		}
+	}
+	return true
+}
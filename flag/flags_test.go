@@ -0,0 +1,47 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestFlags(t *testing.T) {
+	t.Run("SetAll() uint8", func(t *testing.T) {
+		var f = flag.NewFlags[uint8]()
+		if f.SetAll(); f.Value() != 0xFF || f.String() != "11111111" {
+			t.Fatalf("SetAll() = %q, want 8 ones", f.String())
+		}
+	})
+	t.Run("SetAll() uint64", func(t *testing.T) {
+		var f = flag.NewFlags[uint64]()
+		if f.SetAll(); f.Value() != 0xFFFFFFFFFFFFFFFF || len(f.String()) != 64 {
+			t.Fatalf("SetAll() = %q, want 64 ones", f.String())
+		}
+	})
+	t.Run("backed by Flag", func(t *testing.T) {
+		var f = flag.NewFlags[flag.Flag](1, 4)
+		if f.Width() != 32 || !f.HasV(1, 4) || f.Has(2) {
+			t.Fatalf("NewFlags[flag.Flag](1, 4) = %q", f.String())
+		}
+	})
+	t.Run("Toggle() and Clear()", func(t *testing.T) {
+		var f = flag.NewFlags[uint16](1 << 15)
+		f.ToggleV(1, 1<<15).Toggle(2).Set(8).ClearV(8, 1)
+		if f.Value() != 2 {
+			t.Fatalf("got %q, want %q", f.String(), "0000000000000010")
+		}
+		if f.ToggleAll(); f.Value() != 0xFFFD {
+			t.Fatalf("ToggleAll() = %q", f.String())
+		}
+		if f.Clear(1).ClearAll(); f.Value() != 0 {
+			t.Fatalf("ClearAll() = %q", f.String())
+		}
+	})
+	t.Run("String() pads to width", func(t *testing.T) {
+		var f = flag.NewFlags[uint16](42)
+		if got := f.String(); got != "0000000000101010" {
+			t.Fatalf("String() = %q, want %q", got, "0000000000101010")
+		}
+	})
+}
@@ -0,0 +1,498 @@
+package flag
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+/*
+`FlagSet` maps Flag values to human-readable names.
+
+# Example:
+
+	const (
+		Read flag.Flag = 1 << iota
+		Write
+		Execute
+	)
+
+	var names = flag.NewFlagSet()
+	names.Register("Read", Read)
+	names.Register("Write", Write)
+	names.Register("Execute", Execute)
+
+	names.String(Read | Write) // "Read|Write"
+	names.String(Read | 1<<8)  // "Read|0x100"
+
+A FlagSet is safe for concurrent use, so flags can be registered from several goroutines while others
+call `String` or `Parse`. A FlagSet must not be copied after first use.
+*/
+type FlagSet struct {
+	mu      sync.RWMutex
+	entries []entry // sorted by flag value
+	groups  []entry // sorted by most bits set, then by flag value
+	aliases []entry // in registration order
+	byName  map[string]Flag
+
+	exclusive [][]Flag // groups declared with SetExclusive
+	defaults  Flag     // returned by Parse when the parsed value is empty
+	required  Flag     // bits Parse requires in its result
+}
+
+type entry struct {
+	name string
+	flag Flag
+}
+
+// # NewFlagSet returns an empty FlagSet
+func NewFlagSet() *FlagSet {
+	return &FlagSet{byName: make(map[string]Flag)}
+}
+
+// # Register gives `flag` the provided `name`.
+//
+// `flag` may have more than one bit set, but returns an error if `name` is already registered,
+// if `flag` shares any bits with an already registered flag, or if `flag` is zero.
+func (fs *FlagSet) Register(name string, flag Flag) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.register(name, flag)
+}
+
+// # RegisterAll registers every name in `names` like `Register`, so a whole table can be written as a map literal.
+//
+// names are registered in sorted order, so the error returned for a table with several conflicts is always
+// the same one. If any name can't be registered, none of them are.
+func (fs *FlagSet) RegisterAll(names map[string]Flag) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	entries, byName := slices.Clone(fs.entries), maps.Clone(fs.byName)
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		if err := fs.register(name, names[name]); err != nil {
+			fs.entries, fs.byName = entries, byName
+			return err
+		}
+	}
+	return nil
+}
+
+// register is `Register` for callers that already hold `fs.mu` for writing.
+func (fs *FlagSet) register(name string, flag Flag) error {
+	if flag == 0 {
+		return fmt.Errorf("flag: cannot register zero flag as %q", name)
+	}
+	if err := fs.checkName(name); err != nil {
+		return err
+	}
+	for _, e := range fs.entries {
+		if e.flag&flag != 0 {
+			return fmt.Errorf("flag: %q (%s) overlaps %q (%s)", name, flag.Hex(), e.name, e.flag.Hex())
+		}
+	}
+	if fs.byName == nil {
+		fs.byName = make(map[string]Flag)
+	}
+	fs.byName[name] = flag
+	i, _ := slices.BinarySearchFunc(fs.entries, flag, func(e entry, flag Flag) int {
+		return e.flag.Compare(flag)
+	})
+	fs.entries = slices.Insert(fs.entries, i, entry{name: name, flag: flag})
+	return nil
+}
+
+// # RegisterAlias gives an already registered `flag` another name.
+//
+// `Parse` accepts the alias, but `String` keeps using the name `flag` was first registered with.
+// Returns an error if `alias` is already registered or if no flag with exactly the value `flag` is registered.
+func (fs *FlagSet) RegisterAlias(alias string, flag Flag) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := fs.checkName(alias); err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(slices.Concat(fs.entries, fs.groups), func(e entry) bool { return e.flag == flag }) {
+		return fmt.Errorf("flag: cannot alias %q to %s: no flag with that value is registered", alias, flag.Hex())
+	}
+	fs.byName[alias] = flag
+	fs.aliases = append(fs.aliases, entry{name: alias, flag: flag})
+	return nil
+}
+
+// # RegisterGroup gives a common combination of flags a name, like "All" for Read|Write|Execute.
+//
+// unlike `Register`, a group may share bits with registered flags and other groups, but it must have at least two bits set.
+// `Parse` accepts the group name, and `String` uses it whenever all of the group's bits are set.
+func (fs *FlagSet) RegisterGroup(name string, flag Flag) error {
+	if flag.Count() < 2 {
+		return fmt.Errorf("flag: group %q (%s) must have at least two bits set", name, flag.Hex())
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := fs.checkName(name); err != nil {
+		return err
+	}
+	if fs.byName == nil {
+		fs.byName = make(map[string]Flag)
+	}
+	fs.byName[name] = flag
+	i, _ := slices.BinarySearchFunc(fs.groups, flag, func(e entry, flag Flag) int {
+		return cmp.Or(cmp.Compare(flag.Count(), e.flag.Count()), e.flag.Compare(flag))
+	})
+	fs.groups = slices.Insert(fs.groups, i, entry{name: name, flag: flag})
+	return nil
+}
+
+// checkName returns an error if `name` can't be registered. `fs.mu` must be held.
+func (fs *FlagSet) checkName(name string) error {
+	if name == "" || strings.ContainsAny(name, "|") {
+		return fmt.Errorf("flag: invalid name %q", name)
+	}
+	if _, ok := fs.byName[name]; ok {
+		return fmt.Errorf("flag: name %q is already registered", name)
+	}
+	return nil
+}
+
+// # String returns the names of the registered flags set in `b`, joined by "|", e.g. "Read|Write"
+//
+// groups are matched first, greedily: the group with the most bits set (then the lowest value) whose bits are all
+// still unnamed is used, and its bits are removed before trying the next group. The remaining bits are named by
+// the registered flags in ascending bit order. Any leftover bits with no registered name are added as a trailing
+// hex term, e.g. "Read|0x100". An empty flag returns "0x0".
+func (fs *FlagSet) String(b Flag) string {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.string(b)
+}
+
+// string is `String` for callers that already hold `fs.mu`.
+func (fs *FlagSet) string(b Flag) string {
+	names, rest := fs.names(b)
+	if rest != 0 || len(names) == 0 {
+		names = append(names, rest.Hex())
+	}
+	return strings.Join(names, "|")
+}
+
+// names returns the names of the groups and flags set in `b` in `String` order, and the leftover unnamed bits.
+//
+// `fs.mu` must be held.
+func (fs *FlagSet) names(b Flag) (names []string, rest Flag) {
+	for _, e := range slices.Concat(fs.groups, fs.entries) {
+		if b.Has(e.flag) {
+			names = append(names, e.name)
+			b &^= e.flag
+		}
+	}
+	return names, b
+}
+
+// ParseOption changes how `FlagSet.Parse` matches names.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	ignoreCase bool
+	lenient    bool
+	ignored    *[]string
+}
+
+// # IgnoreCase makes `FlagSet.Parse` match names case-insensitively, so "read" matches "Read".
+//
+// an exact match is always preferred.
+func IgnoreCase() ParseOption {
+	return func(o *parseOptions) {
+		o.ignoreCase = true
+	}
+}
+
+// # Lenient makes `FlagSet.Parse` skip unknown names instead of returning an error.
+//
+// if `ignored` isn't nil, each skipped name is appended to it. Empty names (as in "Read||Write") are skipped silently.
+func Lenient(ignored *[]string) ParseOption {
+	return func(o *parseOptions) {
+		o.lenient = true
+		o.ignored = ignored
+	}
+}
+
+// # Parse parses names joined by "|" like "Read|Write" back into a Flag.
+//
+// whitespace around each name is ignored and an empty string returns zero.
+// Hex terms like "0x100" are accepted too, so the output of `String` always parses back.
+// By default names are case-sensitive and an error naming the first unknown name is returned;
+// pass `IgnoreCase()` or `Lenient(...)` to change that.
+//
+// if the result is empty, the flags set with `SetDefaults` are returned instead. An error naming the missing flags
+// is returned if the result doesn't have every flag set with `SetRequired`.
+func (fs *FlagSet) Parse(s string, opts ...ParseOption) (Flag, error) {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var flag = New()
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	if strings.TrimSpace(s) != "" {
+		for _, token := range strings.Split(s, "|") {
+			token = strings.TrimSpace(token)
+			f, ok := fs.lookup(token, o.ignoreCase)
+			switch {
+			case ok:
+				flag |= f
+			case !o.lenient:
+				return 0, fmt.Errorf("flag: unknown flag name %q", token)
+			case o.ignored != nil && token != "":
+				*o.ignored = append(*o.ignored, token)
+			}
+		}
+	}
+	if flag == 0 {
+		flag = fs.defaults
+	}
+	if missing := fs.required &^ flag; missing != 0 {
+		return 0, fmt.Errorf("flag: missing required flag %s in %q", fs.string(missing), s)
+	}
+	return flag, nil
+}
+
+// # SetDefaults sets the flags `Parse` returns when the parsed value is empty, like for an empty string
+//
+// pass zero to go back to returning zero.
+func (fs *FlagSet) SetDefaults(defaults Flag) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.defaults = defaults
+}
+
+// # SetRequired sets the flags that must all be present in the result of `Parse`, after defaults are applied
+//
+// pass zero to stop requiring any. Each `Parse` call is checked on its own, so a `CLIValue` set one flag at a
+// time needs every required flag in each value.
+func (fs *FlagSet) SetRequired(required Flag) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.required = required
+}
+
+// lookup returns the flag registered as `name`, or the value of a hex term like "0x100".
+//
+// `fs.mu` must be held.
+func (fs *FlagSet) lookup(name string, ignoreCase bool) (Flag, bool) {
+	if f, ok := fs.byName[name]; ok {
+		return f, true
+	}
+	if ignoreCase {
+		for _, e := range slices.Concat(fs.entries, fs.groups, fs.aliases) {
+			if strings.EqualFold(e.name, name) {
+				return e.flag, true
+			}
+		}
+	}
+	if len(name) > 2 && strings.EqualFold(name[:2], "0x") {
+		if f, err := ParseHex(name); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// # MarshalFlag encodes `b` as a JSON array of names, e.g. ["Read","Write"]
+//
+// names are in the same order as `String`, and leftover bits with no registered name are added as a hex string,
+// e.g. ["Read","0x100"]. An empty flag encodes as [].
+func (fs *FlagSet) MarshalFlag(b Flag) ([]byte, error) {
+	return json.Marshal(fs.jsonNames(b))
+}
+
+// jsonNames returns the names for `MarshalFlag`, with leftover bits as a hex string and never nil.
+func (fs *FlagSet) jsonNames(b Flag) []string {
+	fs.mu.RLock()
+	names, rest := fs.names(b)
+	fs.mu.RUnlock()
+	if rest != 0 {
+		names = append(names, rest.Hex())
+	}
+	if names == nil {
+		names = []string{}
+	}
+	return names
+}
+
+// # UnmarshalFlag decodes a JSON array of names, as encoded by `MarshalFlag`, back into a Flag
+//
+// returns an error naming the first unknown name. Hex strings like "0x100" are accepted too.
+func (fs *FlagSet) UnmarshalFlag(data []byte) (Flag, error) {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return 0, fmt.Errorf("flag: cannot unmarshal JSON %s into Flag: %w", data, err)
+	}
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	var flag = New()
+	for _, name := range names {
+		f, ok := fs.lookup(name, false)
+		if !ok {
+			return 0, fmt.Errorf("flag: unknown flag name %q", name)
+		}
+		flag |= f
+	}
+	return flag, nil
+}
+
+// verboseJSON is the object form used by `MarshalVerbose` and `UnmarshalVerbose`.
+type verboseJSON struct {
+	Value *Flag     `json:"value,omitempty"`
+	Flags *[]string `json:"flags,omitempty"`
+}
+
+// # MarshalVerbose encodes `b` as a JSON object with both the raw number and the names, e.g.
+// {"value":3,"flags":["Read","Write"]}
+//
+// "flags" holds the same names as `MarshalFlag`, so clients that don't know the names can still read "value".
+func (fs *FlagSet) MarshalVerbose(b Flag) ([]byte, error) {
+	names := fs.jsonNames(b)
+	return json.Marshal(verboseJSON{Value: &b, Flags: &names})
+}
+
+// # UnmarshalVerbose decodes a JSON object encoded by `MarshalVerbose` back into a Flag
+//
+// either "value" or "flags" may be missing. If both are present they must describe the same flag.
+func (fs *FlagSet) UnmarshalVerbose(data []byte) (Flag, error) {
+	// "value" is decoded separately so its error, which already describes the bad number, isn't wrapped twice
+	var raw struct {
+		Value *json.RawMessage `json:"value"`
+		Flags *[]string        `json:"flags"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 0, fmt.Errorf("flag: cannot unmarshal JSON %s into Flag: %w", data, err)
+	}
+	var v = verboseJSON{Flags: raw.Flags}
+	if raw.Value != nil {
+		v.Value = new(Flag)
+		if err := v.Value.UnmarshalJSON(*raw.Value); err != nil {
+			return 0, err
+		}
+	}
+	if v.Flags == nil {
+		if v.Value == nil {
+			return 0, fmt.Errorf("flag: cannot unmarshal JSON %s into Flag: missing \"value\" and \"flags\"", data)
+		}
+		return *v.Value, nil
+	}
+	names, err := json.Marshal(*v.Flags)
+	if err != nil {
+		return 0, err
+	}
+	flag, err := fs.UnmarshalFlag(names)
+	if err != nil {
+		return 0, err
+	}
+	if v.Value != nil && *v.Value != flag {
+		return 0, fmt.Errorf("flag: \"value\" %d does not match \"flags\" %s", *v.Value, names)
+	}
+	return flag, nil
+}
+
+// # Validate checks the whole FlagSet for mistakes, meant to be called after registering a large name table.
+//
+// every bit of a flag added with `Register`, single- or multi-bit, counts as named. Validate reports the groups
+// and aliases with bits that no registered flag covers, which usually means a flag is missing or a group has a typo.
+// Returns an error describing every such name, or nil if there are none.
+//
+// Validate doesn't check registered flags for shared bits: `Register` already returns an error naming the
+// conflict, so a FlagSet can never hold two overlapping flags.
+func (fs *FlagSet) Validate() error {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	var covered Flag
+	for _, e := range fs.entries {
+		covered |= e.flag
+	}
+	var errs []error
+	for _, e := range slices.Concat(fs.groups, fs.aliases) {
+		if missing := e.flag &^ covered; missing != 0 {
+			errs = append(errs, fmt.Errorf("flag: %q (%s) has bits %s with no registered flag", e.name, e.flag.Hex(), missing.Hex()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// # SetExclusive declares that at most one flag of `group` may be set in a value, checked by `Check`
+//
+// a multi-bit flag counts as set when any of its bits is. Returns an error if `group` has fewer than two flags,
+// contains zero, or has flags that share bits, since such a group could never be satisfied.
+func (fs *FlagSet) SetExclusive(group ...Flag) error {
+	if len(group) < 2 {
+		return fmt.Errorf("flag: exclusive group needs at least two flags, got %d", len(group))
+	}
+	var seen Flag
+	for _, f := range group {
+		if f == 0 || seen&f != 0 {
+			return fmt.Errorf("flag: exclusive group flag %s is zero or overlaps another flag in the group", f.Hex())
+		}
+		seen |= f
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.exclusive = append(fs.exclusive, slices.Clone(group))
+	return nil
+}
+
+// # Check returns an error describing every exclusive group (see `SetExclusive`) with more than one flag set in `b`
+//
+// returns nil if `b` satisfies all of them.
+func (fs *FlagSet) Check(b Flag) error {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	var errs []error
+	for _, group := range fs.exclusive {
+		var set []string
+		for _, f := range group {
+			if b.HasAnyIn(f) {
+				set = append(set, fs.string(f))
+			}
+		}
+		if len(set) > 1 {
+			errs = append(errs, fmt.Errorf("flag: %s are mutually exclusive", strings.Join(set, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// # DefaultRegistry is the FlagSet used by `Flag.String`, `RegisterName` and `Parse`.
+//
+// it starts out empty, in which case `Flag.String` prints binary.
+var DefaultRegistry = NewFlagSet()
+
+// # RegisterName registers `name` for `f` in `DefaultRegistry`, see `FlagSet.Register`
+func RegisterName(name string, f Flag) error {
+	return DefaultRegistry.Register(name, f)
+}
+
+// # Parse parses names joined by "|" using `DefaultRegistry`, see `FlagSet.Parse`
+func Parse(s string, opts ...ParseOption) (Flag, error) {
+	return DefaultRegistry.Parse(s, opts...)
+}
+
+// namedString returns `String(b)` if at least one bit of `b` has a name, or false if none do.
+func (fs *FlagSet) namedString(b Flag) (string, bool) {
+	if fs == nil {
+		return "", false
+	}
+	fs.mu.RLock()
+	names, rest := fs.names(b)
+	fs.mu.RUnlock()
+	if len(names) == 0 {
+		return "", false
+	}
+	if rest != 0 {
+		names = append(names, rest.Hex())
+	}
+	return strings.Join(names, "|"), true
+}
@@ -0,0 +1,518 @@
+package flag_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+const (
+	Read flag.Flag = 1 << iota
+	Write
+	Execute
+)
+
+// newPerms returns a FlagSet with Read, Write and Execute registered.
+func newPerms(t *testing.T) *flag.FlagSet {
+	t.Helper()
+	var fs = flag.NewFlagSet()
+	for name, f := range map[string]flag.Flag{"Read": Read, "Write": Write, "Execute": Execute} {
+		if err := fs.Register(name, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fs
+}
+
+func TestFlagSet(t *testing.T) {
+	t.Run("String()", func(t *testing.T) {
+		var fs = newPerms(t)
+		for f, want := range map[flag.Flag]string{
+			0:                      "0x0",
+			Read:                   "Read",
+			Execute | Read:         "Read|Execute",
+			Read | Write | Execute: "Read|Write|Execute",
+			Write | 1<<8 | 1<<4:    "Write|0x110",
+			1 << 31:                "0x80000000",
+		} {
+			if got := fs.String(f); got != want {
+				t.Fatalf("String(%#b) = %q, want %q", uint32(f), got, want)
+			}
+		}
+	})
+	t.Run("multi-bit registrations", func(t *testing.T) {
+		var fs = flag.NewFlagSet()
+		if err := fs.Register("Low", 0b0011); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Register("High", 0b1100); err != nil {
+			t.Fatal(err)
+		}
+		for f, want := range map[flag.Flag]string{
+			0b1111: "Low|High",
+			0b0111: "Low|0x4",
+			0b0001: "0x1",
+		} {
+			if got := fs.String(f); got != want {
+				t.Fatalf("String(%#b) = %q, want %q", uint32(f), got, want)
+			}
+		}
+	})
+	t.Run("Register() errors", func(t *testing.T) {
+		var fs = newPerms(t)
+		for name, f := range map[string]flag.Flag{
+			"Read":    1 << 5,      // duplicate name
+			"ReadAll": Read | 1<<5, // overlapping bits
+			"Zero":    0,
+			"":        1 << 6,
+			"A|B":     1 << 7,
+		} {
+			if err := fs.Register(name, f); err == nil {
+				t.Fatalf("Register(%q, %#b) did not return an error", name, uint32(f))
+			}
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		var fs flag.FlagSet
+		if err := fs.Register("Read", Read); err != nil || fs.String(Read) != "Read" {
+			t.Fatalf("zero FlagSet: Register() = %v, String() = %q", err, fs.String(Read))
+		}
+	})
+}
+
+func TestFlagSetParse(t *testing.T) {
+	var fs = newPerms(t)
+	t.Run("Parse()", func(t *testing.T) {
+		for s, want := range map[string]flag.Flag{
+			"":                      0,
+			"  ":                    0,
+			"Read":                  Read,
+			"Read|Write":            Read | Write,
+			" Execute |  Read ":     Read | Execute,
+			"Write|0x110":           Write | 1<<8 | 1<<4,
+			fs.String(Read | 1<<20): Read | 1<<20,
+		} {
+			if got, err := fs.Parse(s); err != nil || got != want {
+				t.Fatalf("Parse(%q) = %#b, %v, want %#b", s, uint32(got), err, uint32(want))
+			}
+		}
+	})
+	t.Run("unknown names", func(t *testing.T) {
+		for s, name := range map[string]string{
+			"Read|Delete": `"Delete"`,
+			"read":        `"read"`,
+			"Read||Write": `""`,
+			"Read|0xZZ":   `"0xZZ"`,
+		} {
+			if _, err := fs.Parse(s); err == nil || !strings.Contains(err.Error(), name) {
+				t.Fatalf("Parse(%q): err = %v, want an error naming %s", s, err, name)
+			}
+		}
+	})
+	t.Run("IgnoreCase()", func(t *testing.T) {
+		if got, err := fs.Parse("read|WRITE", flag.IgnoreCase()); err != nil || got != Read|Write {
+			t.Fatalf("Parse(\"read|WRITE\", IgnoreCase()) = %#b, %v", uint32(got), err)
+		}
+	})
+}
+
+func TestFlagSetConcurrent(t *testing.T) {
+	var fs = flag.NewFlagSet()
+	var wg sync.WaitGroup
+	for pos := 0; pos < 32; pos++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := fs.Register(fmt.Sprintf("Bit%d", pos), 1<<pos); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			fs.String(0xFFFFFFFF)
+			fs.Parse("Bit0|Bit1", flag.IgnoreCase())
+		}()
+	}
+	wg.Wait()
+	if got, err := fs.Parse(fs.String(0xFFFFFFFF)); err != nil || got != 0xFFFFFFFF {
+		t.Fatalf("Parse(String(0xFFFFFFFF)) = %#x, %v", uint32(got), err)
+	}
+	if got := fs.String(1<<31 | 1); got != "Bit0|Bit31" {
+		t.Fatalf("String(1<<31 | 1) = %q, want %q", got, "Bit0|Bit31")
+	}
+}
+
+func TestFlagSetAlias(t *testing.T) {
+	var fs = newPerms(t)
+	if err := fs.Register("ReadWrite", 1<<4|1<<5); err != nil {
+		t.Fatal(err)
+	}
+	for _, alias := range []string{"rw", "read-write"} {
+		if err := fs.RegisterAlias(alias, 1<<4|1<<5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, s := range []string{"ReadWrite", "rw", "read-write", "RW"} {
+		if got, err := fs.Parse(s, flag.IgnoreCase()); err != nil || got != 1<<4|1<<5 {
+			t.Fatalf("Parse(%q) = %#b, %v", s, uint32(got), err)
+		}
+	}
+	if got := fs.String(Read | 1<<4 | 1<<5); got != "Read|ReadWrite" {
+		t.Fatalf("String() = %q, want %q", got, "Read|ReadWrite")
+	}
+	t.Run("errors", func(t *testing.T) {
+		if err := fs.RegisterAlias("r", 1<<4); err == nil {
+			t.Fatal("RegisterAlias() of an unregistered value did not return an error")
+		}
+		if err := fs.RegisterAlias("rw", Read); err == nil {
+			t.Fatal("RegisterAlias() of a taken name did not return an error")
+		}
+		if err := fs.Register("rw", 1<<6); err == nil {
+			t.Fatal("Register() of a name taken by an alias did not return an error")
+		}
+	})
+}
+
+func TestFlagSetGroup(t *testing.T) {
+	var fs = newPerms(t)
+	for name, f := range map[string]flag.Flag{
+		"All":       Read | Write | Execute,
+		"ReadWrite": Read | Write,
+		"WriteExec": Write | Execute,
+		"Wide":      Read | 1<<4,
+	} {
+		if err := fs.RegisterGroup(name, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Run("String()", func(t *testing.T) {
+		for f, want := range map[flag.Flag]string{
+			Read | Write | Execute:        "All",
+			Read | Write:                  "ReadWrite",
+			Write | Execute:               "WriteExec",
+			Read | Write | Execute | 1<<4: "All|0x10", // All is bigger than Wide
+			Read | 1<<4 | Write:           "ReadWrite|0x10",
+			Read | 1<<4:                   "Wide",
+			Read | Execute:                "Read|Execute",
+		} {
+			if got := fs.String(f); got != want {
+				t.Fatalf("String(%#b) = %q, want %q", uint32(f), got, want)
+			}
+		}
+	})
+	t.Run("Parse()", func(t *testing.T) {
+		if got, err := fs.Parse("All|Wide"); err != nil || got != Read|Write|Execute|1<<4 {
+			t.Fatalf("Parse(\"All|Wide\") = %#b, %v", uint32(got), err)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		if err := fs.RegisterGroup("Single", 1<<8); err == nil {
+			t.Fatal("RegisterGroup() of a single bit did not return an error")
+		}
+		if err := fs.RegisterGroup("Read", 1<<8|1<<9); err == nil {
+			t.Fatal("RegisterGroup() of a taken name did not return an error")
+		}
+	})
+}
+
+func TestFlagSetJSON(t *testing.T) {
+	var fs = newPerms(t)
+	for f, want := range map[flag.Flag]string{
+		0:                   `[]`,
+		Read | Write:        `["Read","Write"]`,
+		Execute | 1<<8:      `["Execute","0x100"]`,
+		1<<8 | 1<<9 | 1<<31: `["0x80000300"]`,
+	} {
+		data, err := fs.MarshalFlag(f)
+		if err != nil || string(data) != want {
+			t.Fatalf("MarshalFlag(%#b) = %s, %v, want %s", uint32(f), data, err, want)
+		}
+		if got, err := fs.UnmarshalFlag(data); err != nil || got != f {
+			t.Fatalf("UnmarshalFlag(%s) = %#b, %v, want %#b", data, uint32(got), err, uint32(f))
+		}
+	}
+	t.Run("errors", func(t *testing.T) {
+		for _, data := range []string{`["Read","Delete"]`, `42`, `["Read"`} {
+			if _, err := fs.UnmarshalFlag([]byte(data)); err == nil {
+				t.Fatalf("UnmarshalFlag(%s) did not return an error", data)
+			}
+		}
+	})
+}
+
+func TestFlagSetVerboseJSON(t *testing.T) {
+	var fs = newPerms(t)
+	t.Run("MarshalVerbose()", func(t *testing.T) {
+		for f, want := range map[flag.Flag]string{
+			Read | Write:   `{"value":3,"flags":["Read","Write"]}`,
+			Execute | 1<<8: `{"value":260,"flags":["Execute","0x100"]}`,
+			0:              `{"value":0,"flags":[]}`,
+		} {
+			data, err := fs.MarshalVerbose(f)
+			if err != nil || string(data) != want {
+				t.Fatalf("MarshalVerbose(%#b) = %s, %v, want %s", uint32(f), data, err, want)
+			}
+			if got, err := fs.UnmarshalVerbose(data); err != nil || got != f {
+				t.Fatalf("UnmarshalVerbose(%s) = %#b, %v, want %#b", data, uint32(got), err, uint32(f))
+			}
+		}
+	})
+	t.Run("missing fields", func(t *testing.T) {
+		for data, want := range map[string]flag.Flag{
+			`{"value":5}`:                Read | Execute,
+			`{"flags":["Read","0x100"]}`: Read | 1<<8,
+		} {
+			if got, err := fs.UnmarshalVerbose([]byte(data)); err != nil || got != want {
+				t.Fatalf("UnmarshalVerbose(%s) = %#b, %v, want %#b", data, uint32(got), err, uint32(want))
+			}
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, data := range []string{`{}`, `{"value":1,"flags":["Write"]}`, `{"flags":["Delete"]}`, `[]`} {
+			if _, err := fs.UnmarshalVerbose([]byte(data)); err == nil {
+				t.Fatalf("UnmarshalVerbose(%s) did not return an error", data)
+			}
+		}
+	})
+	t.Run("bad value", func(t *testing.T) {
+		_, err := fs.UnmarshalVerbose([]byte(`{"value":"0x1"}`))
+		if err == nil || strings.Count(err.Error(), "cannot unmarshal") != 1 {
+			t.Fatalf("UnmarshalVerbose() = %v, want one error that isn't wrapped twice", err)
+		}
+	})
+}
+
+func TestFlagSetParseModes(t *testing.T) {
+	var fs = newPerms(t)
+	t.Run("strict", func(t *testing.T) {
+		if _, err := fs.Parse("Read|Delete"); err == nil {
+			t.Fatal("Parse(\"Read|Delete\") did not return an error")
+		}
+		if _, err := fs.Parse("read|Write"); err == nil {
+			t.Fatal("Parse(\"read|Write\") did not return an error, names should be case-sensitive by default")
+		}
+	})
+	t.Run("Lenient()", func(t *testing.T) {
+		var ignored []string
+		got, err := fs.Parse("Read|Delete| |read|Write|Purge", flag.Lenient(&ignored))
+		if err != nil || got != Read|Write {
+			t.Fatalf("Parse() = %#b, %v, want %#b", uint32(got), err, uint32(Read|Write))
+		}
+		if !slices.Equal(ignored, []string{"Delete", "read", "Purge"}) {
+			t.Fatalf("ignored = %q, want [Delete read Purge]", ignored)
+		}
+		if got, err := fs.Parse("Delete|Execute", flag.Lenient(nil)); err != nil || got != Execute {
+			t.Fatalf("Parse(\"Delete|Execute\", Lenient(nil)) = %#b, %v", uint32(got), err)
+		}
+	})
+	t.Run("Lenient() and IgnoreCase()", func(t *testing.T) {
+		var ignored []string
+		got, err := fs.Parse("read|DELETE|execute", flag.IgnoreCase(), flag.Lenient(&ignored))
+		if err != nil || got != Read|Execute || !slices.Equal(ignored, []string{"DELETE"}) {
+			t.Fatalf("Parse() = %#b, %v, ignored %q", uint32(got), err, ignored)
+		}
+	})
+}
+
+// useRegistry replaces flag.DefaultRegistry with `fs` until the test ends.
+func useRegistry(t *testing.T, fs *flag.FlagSet) {
+	t.Helper()
+	old := flag.DefaultRegistry
+	flag.DefaultRegistry = fs
+	t.Cleanup(func() { flag.DefaultRegistry = old })
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	useRegistry(t, flag.NewFlagSet())
+	if got := Read.String(); got != "00000000000000000000000000000001" {
+		t.Fatalf("String() with an empty registry = %q, want binary", got)
+	}
+	for name, f := range map[string]flag.Flag{"Read": Read, "Write": Write} {
+		if err := flag.RegisterName(name, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for f, want := range map[flag.Flag]string{
+		Read | Write:    "Read|Write",
+		Write | Execute: "Write|0x4",
+		Execute:         "00000000000000000000000000000100",
+		0:               "00000000000000000000000000000000",
+	} {
+		if got := f.String(); got != want {
+			t.Fatalf("Flag(%#b).String() = %q, want %q", uint32(f), got, want)
+		}
+	}
+	if got := fmt.Sprint(Read | Write); got != "Read|Write" {
+		t.Fatalf("fmt.Sprint(Read | Write) = %q, want %q", got, "Read|Write")
+	}
+	if got, err := flag.Parse("Read|Write"); err != nil || got != Read|Write {
+		t.Fatalf("Parse(\"Read|Write\") = %#b, %v", uint32(got), err)
+	}
+	if got := (Read | Execute).LogValue().String(); got != "Read|0x4" {
+		t.Fatalf("LogValue() = %q, want %q", got, "Read|0x4")
+	}
+	if got := Execute.LogValue().String(); got != "0x4" {
+		t.Fatalf("LogValue() of an unnamed flag = %q, want %q", got, "0x4")
+	}
+}
+
+func TestFlagSetValidate(t *testing.T) {
+	t.Run("clean", func(t *testing.T) {
+		var fs = newPerms(t)
+		if err := fs.RegisterGroup("All", Read|Write|Execute); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.RegisterAlias("r", Read); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Validate(); err != nil {
+			t.Fatalf("Validate() = %v, want nil", err)
+		}
+	})
+	t.Run("multi-bit flags", func(t *testing.T) {
+		var fs = flag.NewFlagSet()
+		if err := fs.Register("ReadWrite", Read|Write); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Register("Exec", Execute); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.RegisterGroup("All", Read|Write|Execute); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Validate(); err != nil {
+			t.Fatalf("Validate() = %v, want nil", err)
+		}
+	})
+	t.Run("uncovered bits", func(t *testing.T) {
+		var fs = newPerms(t)
+		if err := fs.Register("Admin", 1<<4|1<<5); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.RegisterGroup("Everything", Read|Write|Execute|1<<8); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.RegisterAlias("Any", Read|Write|Execute|1<<8); err != nil {
+			t.Fatal(err)
+		}
+		err := fs.Validate()
+		for _, want := range []string{`"Everything" (0x107) has bits 0x100`, `"Any" (0x107) has bits 0x100`} {
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("Validate() = %v, want an error containing %s", err, want)
+			}
+		}
+		if strings.Contains(err.Error(), "Admin") {
+			t.Fatalf("Validate() = %v, want the multi-bit flag Admin to count as covered", err)
+		}
+	})
+	t.Run("overlapping bits", func(t *testing.T) {
+		var fs = newPerms(t)
+		err := fs.Register("ReadWrite", Read|Write)
+		if err == nil || !strings.Contains(err.Error(), `"ReadWrite" (0x3) overlaps "Read" (0x1)`) {
+			t.Fatalf("Register() = %v, want an error naming the conflict with Read", err)
+		}
+		if err := fs.Validate(); err != nil {
+			t.Fatalf("Validate() after a rejected overlap = %v, want nil", err)
+		}
+	})
+}
+
+func TestFlagSetExclusive(t *testing.T) {
+	var fs = newPerms(t)
+	if err := fs.SetExclusive(Write, Execute); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("satisfied", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, Read, Read | Write, Read | Execute} {
+			if err := fs.Check(f); err != nil {
+				t.Fatalf("Check(%s) = %v, want nil", fs.String(f), err)
+			}
+		}
+	})
+	t.Run("violated", func(t *testing.T) {
+		err := fs.Check(Read | Write | Execute)
+		if err == nil || !strings.Contains(err.Error(), "Write, Execute") {
+			t.Fatalf("Check(Read|Write|Execute) = %v, want an error naming Write and Execute", err)
+		}
+	})
+	t.Run("invalid groups", func(t *testing.T) {
+		for _, group := range [][]flag.Flag{nil, {Read}, {Read, 0}, {Read, Read | Write}} {
+			if err := fs.SetExclusive(group...); err == nil {
+				t.Fatalf("SetExclusive(%v) = nil, want an error", group)
+			}
+		}
+	})
+}
+
+func TestFlagSetDefaultsAndRequired(t *testing.T) {
+	t.Run("SetDefaults()", func(t *testing.T) {
+		var fs = newPerms(t)
+		fs.SetDefaults(Read | Write)
+		for s, want := range map[string]flag.Flag{
+			"":        Read | Write,
+			"  ":      Read | Write,
+			"Execute": Execute,
+			"0x0":     Read | Write,
+		} {
+			if got, err := fs.Parse(s); err != nil || got != want {
+				t.Fatalf("Parse(%q) = %s, %v, want %s", s, fs.String(got), err, fs.String(want))
+			}
+		}
+	})
+	t.Run("SetRequired()", func(t *testing.T) {
+		var fs = newPerms(t)
+		fs.SetRequired(Read)
+		if got, err := fs.Parse("Read|Write"); err != nil || got != Read|Write {
+			t.Fatalf("Parse(%q) = %s, %v, want Read|Write", "Read|Write", fs.String(got), err)
+		}
+		for _, s := range []string{"Write", ""} {
+			if _, err := fs.Parse(s); err == nil || !strings.Contains(err.Error(), "Read") {
+				t.Fatalf("Parse(%q) = %v, want an error naming Read", s, err)
+			}
+		}
+	})
+	t.Run("defaults satisfy required", func(t *testing.T) {
+		var fs = newPerms(t)
+		fs.SetDefaults(Read)
+		fs.SetRequired(Read)
+		if got, err := fs.Parse(""); err != nil || got != Read {
+			t.Fatalf("Parse(%q) = %s, %v, want Read", "", fs.String(got), err)
+		}
+	})
+}
+
+func TestFlagSetRegisterAll(t *testing.T) {
+	t.Run("clean", func(t *testing.T) {
+		var fs = flag.NewFlagSet()
+		if err := fs.RegisterAll(map[string]flag.Flag{"Read": Read, "Write": Write, "Execute": Execute}); err != nil {
+			t.Fatal(err)
+		}
+		if got := fs.String(Read | Write | Execute); got != "Read|Write|Execute" {
+			t.Fatalf("String() = %q, want %q", got, "Read|Write|Execute")
+		}
+	})
+	t.Run("duplicate name", func(t *testing.T) {
+		var fs = newPerms(t)
+		err := fs.RegisterAll(map[string]flag.Flag{"Delete": 1 << 3, "Read": 1 << 4})
+		if err == nil || !strings.Contains(err.Error(), `"Read"`) {
+			t.Fatalf("RegisterAll() = %v, want an error naming Read", err)
+		}
+		if _, err := fs.Parse("Delete"); err == nil {
+			t.Fatal("RegisterAll() registered Delete despite failing")
+		}
+	})
+	t.Run("overlapping bits", func(t *testing.T) {
+		var fs = flag.NewFlagSet()
+		err := fs.RegisterAll(map[string]flag.Flag{"A": 1, "B": 2, "C": 1})
+		if err == nil || !strings.Contains(err.Error(), `"C" (0x1) overlaps "A" (0x1)`) {
+			t.Fatalf("RegisterAll() = %v, want C to overlap A", err)
+		}
+		if got := fs.String(3); got != "0x3" {
+			t.Fatalf("String(3) after a failed RegisterAll() = %q, want %q", got, "0x3")
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package flag
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// # Hex returns the hexadecimal formatted string with a "0x" prefix, e.g. "0x2a"
+func (b Flag) Hex() string {
+	return "0x" + strconv.FormatUint(uint64(b), 16)
+}
+
+// # Format implements the fmt.Formatter interface
+//
+// `%v` and `%s` print `String()`, which uses the `DefaultRegistry` names when any set bit has one and binary otherwise.
+// Numeric verbs like `%b`, `%o`, `%x`, `%X` and `%d` print the underlying `uint32`.
+// Width, precision and flags such as `%08b` and `%#x` are preserved.
+// `%#v` prints `GoString()`.
+func (b Flag) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, b.GoString())
+	case verb == 'v', verb == 's', verb == 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), b.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), uint32(b))
+	}
+}
+
+// # GoString returns a Go-syntax representation like "flag.Flag(0b101010)"
+//
+// implements the fmt.GoStringer interface, used by `%#v`.
+func (b Flag) GoString() string {
+	return "flag.Flag(0b" + strconv.FormatUint(uint64(b), 2) + ")"
+}
+
+// # LogValue returns the flag as a string value so structured logs stay readable
+//
+// uses the names registered in `DefaultRegistry` like "Read|Write" when there are any for `b`, or hex like "0x2a" otherwise.
+//
+// implements the slog.LogValuer interface
+func (b Flag) LogValue() slog.Value {
+	if s, ok := DefaultRegistry.namedString(b); ok {
+		return slog.StringValue(s)
+	}
+	return slog.StringValue(b.Hex())
+}
+
+// # Format32 returns the full 32-bit binary string, zero-padded and MSB-first (bit 31 on the left)
+//
+// unlike `String`, it never uses names from `DefaultRegistry`.
+func (b Flag) Format32() string {
+	return fmt.Sprintf("%032b", uint32(b))
+}
+
+// # StringMSBFirst returns the lowest `width` bits as a binary string, MSB-first (bit `width-1` on the left)
+//
+// higher bits are left out. Panics unless `0 <= width <= 32`.
+func (b Flag) StringMSBFirst(width int) string {
+	b &= rangeMask(0, width)
+	if width == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%0*b", width, uint32(b))
+}
+
+// # StringLSBFirst returns the lowest `width` bits as a binary string, LSB-first (bit 0 on the left)
+//
+// for documentation that numbers bits left-to-right. Panics unless `0 <= width <= 32`.
+func (b Flag) StringLSBFirst(width int) string {
+	s := []byte(b.StringMSBFirst(width))
+	slices.Reverse(s)
+	return string(s)
+}
+
+// # StringGrouped returns the 32-bit binary string with `sep` between every `groupSize` bits, e.g.
+// "0000_0000_0000_0000_0000_0000_0010_1010" for `StringGrouped(4, "_")`
+//
+// groups are counted from the least-significant end, so when `groupSize` doesn't divide 32 the leftmost group is shorter.
+// A `groupSize` of 0 or less returns the ungrouped string.
+func (b Flag) StringGrouped(groupSize int, sep string) string {
+	bin := b.Format32()
+	if groupSize <= 0 {
+		return bin
+	}
+	var sb strings.Builder
+	for i := range bin {
+		if i > 0 && (len(bin)-i)%groupSize == 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteByte(bin[i])
+	}
+	return sb.String()
+}
+
+// # AppendBinary appends the 32-bit binary form of `b` (as `Format32` returns it) to `dst` and returns the extended slice
+//
+// follows the `strconv.AppendInt` convention, so reusing `dst` formats without allocating.
+func (b Flag) AppendBinary(dst []byte) []byte {
+	for pos := 31; pos >= 0; pos-- {
+		dst = append(dst, '0'+byte(b>>pos&1))
+	}
+	return dst
+}
+
+// # AppendHex appends the hexadecimal form of `b` (as `Hex` returns it, e.g. "0x2a") to `dst` and returns the extended slice
+func (b Flag) AppendHex(dst []byte) []byte {
+	return strconv.AppendUint(append(dst, "0x"...), uint64(b), 16)
+}
@@ -0,0 +1,172 @@
+package flag_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestHex(t *testing.T) {
+	for f, want := range map[flag.Flag]string{
+		0:          "0x0",
+		42:         "0x2a",
+		0xFFFFFFFF: "0xffffffff",
+	} {
+		if got := f.Hex(); got != want {
+			t.Fatalf("Flag(%d).Hex() = %q, want %q", uint32(f), got, want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var f flag.Flag = 42
+	for format, want := range map[string]string{
+		"%#x":  "0x2a",
+		"%X":   "2A",
+		"%08x": "0000002a",
+		"%08b": "00101010",
+		"%b":   "101010",
+		"%o":   "52",
+		"%d":   "42",
+		"%5d":  "   42",
+		"%-4d": "42  ",
+		"%v":   "00000000000000000000000000101010",
+		"%s":   "00000000000000000000000000101010",
+		"%34v": "  00000000000000000000000000101010",
+	} {
+		if got := fmt.Sprintf(format, f); got != want {
+			t.Fatalf("fmt.Sprintf(%q, f) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestGoString(t *testing.T) {
+	for f, want := range map[flag.Flag]string{
+		0:  "flag.Flag(0b0)",
+		42: "flag.Flag(0b101010)",
+	} {
+		if got := f.GoString(); got != want {
+			t.Fatalf("Flag(%d).GoString() = %q, want %q", uint32(f), got, want)
+		}
+	}
+	var s = struct{ F flag.Flag }{F: 5}
+	if got := fmt.Sprintf("%#v", s); got != "struct { F flag.Flag }{F:flag.Flag(0b101)}" {
+		t.Fatalf("fmt.Sprintf(\"%%#v\", s) = %q", got)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	var logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("updated", "flags", flag.Flag(42), slog.Group("user", "perms", flag.Flag(0x100)))
+	for _, want := range []string{`"flags":"0x2a"`, `"user":{"perms":"0x100"}`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("logged %s, want it to contain %s", buf.String(), want)
+		}
+	}
+}
+
+func TestBitOrderStrings(t *testing.T) {
+	var f flag.Flag = 0x80000006
+	if got := f.Format32(); got != "10000000000000000000000000000110" {
+		t.Fatalf("Format32() = %q", got)
+	}
+	for width, want := range map[int][2]string{
+		0:  {"", ""},
+		4:  {"0110", "0110"},
+		6:  {"000110", "011000"},
+		32: {"10000000000000000000000000000110", "01100000000000000000000000000001"},
+	} {
+		if got := f.StringMSBFirst(width); got != want[0] {
+			t.Fatalf("StringMSBFirst(%d) = %q, want %q", width, got, want[0])
+		}
+		if got := f.StringLSBFirst(width); got != want[1] {
+			t.Fatalf("StringLSBFirst(%d) = %q, want %q", width, got, want[1])
+		}
+	}
+	if got := flag.Flag(1).StringLSBFirst(3); got != "100" {
+		t.Fatalf("Flag(1).StringLSBFirst(3) = %q, want %q", got, "100")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("StringMSBFirst(33) did not panic")
+		}
+	}()
+	f.StringMSBFirst(33)
+}
+
+func TestStringGrouped(t *testing.T) {
+	var f flag.Flag = 42
+	for _, c := range []struct {
+		size int
+		sep  string
+		want string
+	}{
+		{4, "_", "0000_0000_0000_0000_0000_0000_0010_1010"},
+		{8, " ", "00000000 00000000 00000000 00101010"},
+		{8, " | ", "00000000 | 00000000 | 00000000 | 00101010"},
+		{5, "_", "00_00000_00000_00000_00000_00001_01010"},
+		{32, "_", "00000000000000000000000000101010"},
+		{0, "_", "00000000000000000000000000101010"},
+	} {
+		if got := f.StringGrouped(c.size, c.sep); got != c.want {
+			t.Fatalf("StringGrouped(%d, %q) = %q, want %q", c.size, c.sep, got, c.want)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	var f flag.Flag = 0x8000002A
+	if got, want := string(f.AppendBinary([]byte("f="))), "f="+f.Format32(); got != want {
+		t.Fatalf("AppendBinary() = %q, want %q", got, want)
+	}
+	if got, want := string(f.AppendHex([]byte("f="))), "f=0x8000002a"; got != want {
+		t.Fatalf("AppendHex() = %q, want %q", got, want)
+	}
+	if got := string(flag.Flag(0).AppendHex(nil)); got != "0x0" {
+		t.Fatalf("AppendHex(nil) of zero = %q, want %q", got, "0x0")
+	}
+}
+
+// bytesSink and stringSink keep formatted output alive so the compiler can't drop the work.
+var (
+	bytesSink  []byte
+	stringSink string
+)
+
+// BenchmarkFormat compares the Append methods, reusing a buffer, against their string-returning versions.
+func BenchmarkFormat(b *testing.B) {
+	var f flag.Flag = 0x8000002A
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			stringSink = f.String()
+		}
+	})
+	b.Run("AppendBinary", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf = make([]byte, 0, 32)
+		for i := 0; i < b.N; i++ {
+			buf = f.AppendBinary(buf[:0])
+		}
+		bytesSink = buf
+	})
+	b.Run("Hex", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			stringSink = f.Hex()
+		}
+	})
+	b.Run("AppendHex", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf = make([]byte, 0, 10)
+		for i := 0; i < b.N; i++ {
+			buf = f.AppendHex(buf[:0])
+		}
+		bytesSink = buf
+	})
+}
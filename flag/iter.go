@@ -0,0 +1,68 @@
+package flag
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// # Range calls `fn` with the position (0-31) of each set bit, in ascending order.
+//
+// Stops early if `fn` returns false. `fn` is never called for an empty flag.
+func (b Flag) Range(fn func(pos int) bool) {
+	for b != 0 {
+		pos := bits.TrailingZeros32(uint32(b))
+		if !fn(pos) {
+			return
+		}
+		b &= b - 1 // clear the lowest set bit
+	}
+}
+
+// # Bits returns an iterator over the position (0-31) of each set bit, in ascending order.
+//
+//	for pos := range f.Bits() {
+//		...
+//	}
+func (b Flag) Bits() iter.Seq[int] {
+	return b.Range
+}
+
+// # Masks returns an iterator over each set bit as a single-bit Flag, in ascending order.
+func (b Flag) Masks() iter.Seq[Flag] {
+	return b.ForEachMask
+}
+
+// # ForEachMask calls `fn` with each set bit as a single-bit Flag, in ascending order.
+//
+// Stops early if `fn` returns false. `fn` is never called for an empty flag.
+func (b Flag) ForEachMask(fn func(mask Flag) bool) {
+	for b != 0 {
+		mask := b & -b // isolate the lowest set bit
+		if !fn(mask) {
+			return
+		}
+		b &^= mask
+	}
+}
+
+// # Flags returns each set bit as a single-bit Flag (a power of two), in ascending order.
+//
+// returns an empty (non-nil) slice for an empty flag.
+func (b Flag) Flags() []Flag {
+	flags := make([]Flag, 0, b.Count())
+	for mask := range b.Masks() {
+		flags = append(flags, mask)
+	}
+	return flags
+}
+
+// # Indices returns the position (0-31) of each set bit, in ascending order.
+//
+// returns an empty (non-nil) slice for an empty flag.
+func (b Flag) Indices() []int {
+	indices := make([]int, 0, b.Count())
+	for pos := range b.Bits() {
+		indices = append(indices, pos)
+	}
+	return indices
+}
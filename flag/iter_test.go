@@ -0,0 +1,118 @@
+package flag_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestRange(t *testing.T) {
+	t.Run("ascending", func(t *testing.T) {
+		var got []int
+		flag.Flag(0x80000025).Range(func(pos int) bool {
+			got = append(got, pos)
+			return true
+		})
+		if !slices.Equal(got, []int{0, 2, 5, 31}) {
+			t.Fatalf("Range() visited %v, want [0 2 5 31]", got)
+		}
+	})
+	t.Run("early stop", func(t *testing.T) {
+		var got []int
+		flag.Flag(0b10110).Range(func(pos int) bool {
+			got = append(got, pos)
+			return len(got) < 2
+		})
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Fatalf("Range() visited %v, want [1 2]", got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		flag.New().Range(func(pos int) bool {
+			t.Fatalf("Range() on an empty flag called fn(%d)", pos)
+			return true
+		})
+	})
+}
+
+func TestIterators(t *testing.T) {
+	var f flag.Flag = 0x80000025
+	t.Run("Bits()", func(t *testing.T) {
+		if got := slices.Collect(f.Bits()); !slices.Equal(got, []int{0, 2, 5, 31}) {
+			t.Fatalf("Bits() = %v, want [0 2 5 31]", got)
+		}
+	})
+	t.Run("Masks()", func(t *testing.T) {
+		if got := slices.Collect(f.Masks()); !slices.Equal(got, []flag.Flag{1, 1 << 2, 1 << 5, 1 << 31}) {
+			t.Fatalf("Masks() = %v", got)
+		}
+	})
+	t.Run("break", func(t *testing.T) {
+		var positions []int
+		for pos := range f.Bits() {
+			if pos > 2 {
+				break
+			}
+			positions = append(positions, pos)
+		}
+		if !slices.Equal(positions, []int{0, 2}) {
+			t.Fatalf("Bits() with break = %v, want [0 2]", positions)
+		}
+		var masks []flag.Flag
+		for mask := range f.Masks() {
+			masks = append(masks, mask)
+			break
+		}
+		if !slices.Equal(masks, []flag.Flag{1}) {
+			t.Fatalf("Masks() with break = %v, want [1]", masks)
+		}
+	})
+}
+
+func TestFlagsSlice(t *testing.T) {
+	if got := flag.Flag(0b101010).Flags(); !slices.Equal(got, []flag.Flag{0b10, 0b1000, 0b100000}) {
+		t.Fatalf("Flag(0b101010).Flags() = %v", got)
+	}
+	if got := flag.New().Flags(); got == nil || len(got) != 0 {
+		t.Fatalf("New().Flags() = %#v, want empty non-nil slice", got)
+	}
+}
+
+func TestIndices(t *testing.T) {
+	var all = flag.New()
+	all.SetAll()
+	var want []int
+	for i := 0; i < 32; i++ {
+		want = append(want, i)
+	}
+	for f, want := range map[flag.Flag][]int{
+		1 << 7:   {7},
+		0b101010: {1, 3, 5},
+		all:      want,
+		0:        {},
+	} {
+		if got := f.Indices(); got == nil || !slices.Equal(got, want) {
+			t.Fatalf("Flag(%#x).Indices() = %v, want %v", uint32(f), got, want)
+		}
+	}
+}
+
+func TestForEachMask(t *testing.T) {
+	t.Run("early stop", func(t *testing.T) {
+		var got []flag.Flag
+		flag.Flag(0b10110).ForEachMask(func(mask flag.Flag) bool {
+			got = append(got, mask)
+			return mask < 0b100
+		})
+		if !slices.Equal(got, []flag.Flag{0b10, 0b100}) {
+			t.Fatalf("ForEachMask() visited %v, want [0b10 0b100]", got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		flag.New().ForEachMask(func(mask flag.Flag) bool {
+			t.Fatalf("ForEachMask() on an empty flag called fn(%#x)", uint32(mask))
+			return true
+		})
+	})
+}
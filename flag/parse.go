@@ -0,0 +1,108 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parse parses `s` as a uint32 in the given base, with an optional (case-insensitive) `prefix`.
+//
+// errors wrap `strconv.ErrSyntax` or `strconv.ErrRange` so callers can check them with `errors.Is`.
+func parse(fn, s string, base int, prefix string) (Flag, error) {
+	digits := s
+	if len(digits) >= len(prefix) && strings.EqualFold(digits[:len(prefix)], prefix) {
+		digits = digits[len(prefix):]
+	}
+	n, err := strconv.ParseUint(digits, base, 32)
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err
+		}
+		return 0, fmt.Errorf("flag: %s(%q): %w", fn, s, err)
+	}
+	return Flag(n), nil
+}
+
+// # ParseBinary parses a binary string like "0010" or "0b101010" into a Flag.
+//
+// returns an error for characters other than `0` and `1` or for values that don't fit in 32 bits.
+func ParseBinary(s string) (Flag, error) {
+	return parse("ParseBinary", s, 2, "0b")
+}
+
+// # ParseHex parses a hexadecimal string like "2a", "0x2a" or "0X2A" into a Flag.
+//
+// returns an error for invalid hex digits or for values greater than `0xFFFFFFFF`.
+func ParseHex(s string) (Flag, error) {
+	return parse("ParseHex", s, 16, "0x")
+}
+
+// parseNumber parses `s` as hexadecimal with a "0x" prefix, binary with a "0b" prefix, or plain decimal otherwise.
+func parseNumber(fn, s string) (Flag, error) {
+	if len(s) >= 2 {
+		switch strings.ToLower(s[:2]) {
+		case "0x":
+			return parse(fn, s, 16, "0x")
+		case "0b":
+			return parse(fn, s, 2, "0b")
+		}
+	}
+	return parse(fn, s, 10, "")
+}
+
+// # MustParseBinary is like `ParseBinary` but panics if `s` can't be parsed.
+//
+// meant for package-level variables and tests, like `regexp.MustCompile`.
+func MustParseBinary(s string) Flag {
+	flag, err := ParseBinary(s)
+	if err != nil {
+		panic(err)
+	}
+	return flag
+}
+
+// # FmtScanner returns a fmt.Scanner that scans into `b`, for use with `fmt.Sscan` and friends:
+//
+//	var f flag.Flag
+//	fmt.Sscanf("0x2a", "%v", f.FmtScanner())
+//
+// Flag can't implement fmt.Scanner itself because its `Scan` method already implements sql.Scanner.
+//
+// `%v` and `%s` accept hex with a "0x" prefix, binary with a "0b" prefix and plain decimal.
+// `%x`/`%X` always read hex, `%b` binary and `%d` decimal, with an optional prefix.
+func (b *Flag) FmtScanner() fmt.Scanner {
+	return (*flagScanner)(b)
+}
+
+type flagScanner Flag
+
+func (s *flagScanner) Scan(state fmt.ScanState, verb rune) error {
+	state.SkipSpace()
+	token, err := state.Token(false, func(r rune) bool {
+		return '0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+	})
+	if err != nil {
+		return err
+	}
+	var flag Flag
+	switch verb {
+	case 'v', 's':
+		flag, err = parseNumber("Scan", string(token))
+	case 'x', 'X':
+		flag, err = parse("Scan", string(token), 16, "0x")
+	case 'b':
+		flag, err = parse("Scan", string(token), 2, "0b")
+	case 'd':
+		flag, err = parse("Scan", string(token), 10, "")
+	default:
+		return fmt.Errorf("flag: Scan: unsupported verb %%%c", verb)
+	}
+	if err != nil {
+		return err
+	}
+	*s = flagScanner(flag)
+	return nil
+}
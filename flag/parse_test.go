@@ -0,0 +1,114 @@
+package flag_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestParseBinary(t *testing.T) {
+	for s, want := range map[string]flag.Flag{
+		"0010":                                0b10,
+		"0b101010":                            42,
+		"0B1":                                 1,
+		"00000000000000000000000000000000001": 1,
+		strings.Repeat("1", 32):               0xFFFFFFFF,
+	} {
+		if got, err := flag.ParseBinary(s); err != nil || got != want {
+			t.Fatalf("ParseBinary(%q) = %#b, %v, want %#b", s, uint32(got), err, uint32(want))
+		}
+	}
+	t.Run("overflow", func(t *testing.T) {
+		if _, err := flag.ParseBinary("1" + strings.Repeat("0", 32)); !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("ParseBinary() of 33 bits: err = %v, want strconv.ErrRange", err)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"", "0b", "0102", "-1", "0x1"} {
+			if _, err := flag.ParseBinary(s); !errors.Is(err, strconv.ErrSyntax) {
+				t.Fatalf("ParseBinary(%q): err = %v, want strconv.ErrSyntax", s, err)
+			}
+		}
+	})
+}
+
+func TestParseHex(t *testing.T) {
+	for s, want := range map[string]flag.Flag{
+		"2a":         42,
+		"0x2a":       42,
+		"0X2A":       42,
+		"0xFFFFFFFF": 0xFFFFFFFF,
+	} {
+		if got, err := flag.ParseHex(s); err != nil || got != want {
+			t.Fatalf("ParseHex(%q) = %#x, %v, want %#x", s, uint32(got), err, uint32(want))
+		}
+	}
+	t.Run("round trip", func(t *testing.T) {
+		for _, f := range []flag.Flag{0, 1, 0xDEADBEEF, 0xFFFFFFFF} {
+			if got, err := flag.ParseHex(f.Hex()); err != nil || got != f {
+				t.Fatalf("ParseHex(%q) = %#x, %v", f.Hex(), uint32(got), err)
+			}
+		}
+	})
+	t.Run("overflow", func(t *testing.T) {
+		if _, err := flag.ParseHex("0x100000000"); !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("ParseHex(\"0x100000000\"): err = %v, want strconv.ErrRange", err)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"", "0x", "0xg1", "2a!", "-1"} {
+			if _, err := flag.ParseHex(s); !errors.Is(err, strconv.ErrSyntax) {
+				t.Fatalf("ParseHex(%q): err = %v, want strconv.ErrSyntax", s, err)
+			}
+		}
+	})
+}
+
+func TestMustParseBinary(t *testing.T) {
+	if got := flag.MustParseBinary("0b101010"); got != 42 {
+		t.Fatalf("MustParseBinary(\"0b101010\") = %#b, want 0b101010", uint32(got))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustParseBinary(\"0b2\") did not panic")
+		}
+	}()
+	flag.MustParseBinary("0b2")
+}
+
+func TestFmtScanner(t *testing.T) {
+	for _, c := range []struct {
+		input, format string
+		want          flag.Flag
+	}{
+		{"0x2a", "%v", 42},
+		{"0b101010", "%v", 42},
+		{"42", "%v", 42},
+		{"  0X2A", "%s", 42},
+		{"2a", "%x", 42},
+		{"101010", "%b", 42},
+		{"0b101010", "%b", 42},
+		{"42", "%d", 42},
+	} {
+		var f flag.Flag
+		if _, err := fmt.Sscanf(c.input, c.format, f.FmtScanner()); err != nil || f != c.want {
+			t.Fatalf("fmt.Sscanf(%q, %q) = %d, %v, want %d", c.input, c.format, uint32(f), err, uint32(c.want))
+		}
+	}
+	t.Run("Sscan() several values", func(t *testing.T) {
+		var a, b flag.Flag
+		if _, err := fmt.Sscan("0b11 0x10", a.FmtScanner(), b.FmtScanner()); err != nil || a != 3 || b != 16 {
+			t.Fatalf("fmt.Sscan() = %d, %d, %v, want 3, 16", uint32(a), uint32(b), err)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var f flag.Flag
+		if _, err := fmt.Sscanf("12", "%b", f.FmtScanner()); err == nil {
+			t.Fatalf("fmt.Sscanf(%q, %q) did not return an error", "12", "%b")
+		}
+	})
+}
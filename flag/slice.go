@@ -0,0 +1,55 @@
+package flag
+
+import (
+	"cmp"
+	"slices"
+)
+
+// # CountAll returns the total number of set bits across all of `flags`
+func CountAll(flags []Flag) int {
+	var n int
+	for _, flag := range flags {
+		n += flag.Count()
+	}
+	return n
+}
+
+// # Histogram returns, for each bit position 0-31, how many of `flags` have that bit set
+func Histogram(flags []Flag) [32]int {
+	var hist [32]int
+	for _, flag := range flags {
+		for pos := range flag.Bits() {
+			hist[pos]++
+		}
+	}
+	return hist
+}
+
+// # Sort sorts `flags` in place by raw numeric value, the same ordering as `Compare`
+func Sort(flags []Flag) {
+	slices.SortFunc(flags, Flag.Compare)
+}
+
+// # SortByCount sorts `flags` in place by the number of bits set, and flags with the same count by raw numeric value
+//
+// the sort is stable.
+func SortByCount(flags []Flag) {
+	slices.SortStableFunc(flags, func(a, b Flag) int {
+		return cmp.Or(cmp.Compare(a.Count(), b.Count()), a.Compare(b))
+	})
+}
+
+// # Dedup returns a new slice with the first occurrence of each flag in `flags`, in the order they were first seen
+//
+// `flags` is not modified, and the result is never nil.
+func Dedup(flags []Flag) []Flag {
+	var seen = make(map[Flag]bool, len(flags))
+	var out = make([]Flag, 0, len(flags))
+	for _, flag := range flags {
+		if !seen[flag] {
+			seen[flag] = true
+			out = append(out, flag)
+		}
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package flag_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestCountAll(t *testing.T) {
+	for _, c := range []struct {
+		flags []flag.Flag
+		want  int
+	}{
+		{nil, 0},
+		{[]flag.Flag{0b1011}, 3},
+		{[]flag.Flag{0b0011, 0b0110, 0xFFFFFFFF}, 36},
+	} {
+		if got := flag.CountAll(c.flags); got != c.want {
+			t.Fatalf("CountAll(%v) = %d, want %d", c.flags, got, c.want)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	if got := flag.Histogram(nil); got != [32]int{} {
+		t.Fatalf("Histogram(nil) = %v, want all zeros", got)
+	}
+	if got := flag.Histogram([]flag.Flag{1 << 31}); got != [32]int{31: 1} {
+		t.Fatalf("Histogram([1<<31]) = %v", got)
+	}
+	if got := flag.Histogram([]flag.Flag{0b0011, 0b0110, 0b0010}); got != [32]int{0: 1, 1: 3, 2: 1} {
+		t.Fatalf("Histogram([0b11 0b110 0b10]) = %v", got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	var flags = []flag.Flag{0b0111, 1 << 31, 0b1000, 0, 0b0011, 0b1000}
+	flag.Sort(flags)
+	if want := []flag.Flag{0, 0b0011, 0b0111, 0b1000, 0b1000, 1 << 31}; !slices.Equal(flags, want) {
+		t.Fatalf("Sort() = %#x, want %#x", flags, want)
+	}
+	flag.SortByCount(flags)
+	if want := []flag.Flag{0, 0b1000, 0b1000, 1 << 31, 0b0011, 0b0111}; !slices.Equal(flags, want) {
+		t.Fatalf("SortByCount() = %#x, want %#x", flags, want)
+	}
+	flag.Sort(nil)
+	flag.SortByCount(nil)
+}
+
+func TestDedup(t *testing.T) {
+	for _, c := range []struct {
+		flags, want []flag.Flag
+	}{
+		{nil, []flag.Flag{}},
+		{[]flag.Flag{4, 1, 2}, []flag.Flag{4, 1, 2}},
+		{[]flag.Flag{4, 1, 4, 0, 1, 0, 2}, []flag.Flag{4, 1, 0, 2}},
+	} {
+		var in = slices.Clone(c.flags)
+		got := flag.Dedup(c.flags)
+		if got == nil || !slices.Equal(got, c.want) {
+			t.Fatalf("Dedup(%#x) = %#x, want %#x", c.flags, got, c.want)
+		}
+		if !slices.Equal(c.flags, in) {
+			t.Fatalf("Dedup() modified its input: %#x, want %#x", c.flags, in)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package flag
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// # Value returns the flag as an `int64` for storing in a database integer column
+//
+// implements the driver.Valuer interface
+func (b Flag) Value() (driver.Value, error) {
+	return int64(b), nil
+}
+
+// # Scan reads a database value into the flag
+//
+// accepts `int64`, `[]byte` and `string` (decimal, or hex/binary with a "0x"/"0b" prefix) and `nil` (zero).
+// Values outside the uint32 range return an error instead of being truncated.
+//
+// implements the sql.Scanner interface
+func (b *Flag) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*b = 0
+	case int64:
+		if src < 0 || src > 0xFFFFFFFF {
+			return fmt.Errorf("flag: cannot scan %d into Flag: out of uint32 range", src)
+		}
+		*b = Flag(src)
+	case []byte:
+		return b.UnmarshalText(src)
+	case string:
+		return b.UnmarshalText([]byte(src))
+	default:
+		return fmt.Errorf("flag: cannot scan %T into Flag", src)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestSQL(t *testing.T) {
+	t.Run("Value()", func(t *testing.T) {
+		v, err := flag.Flag(0xFFFFFFFF).Value()
+		if err != nil || v != int64(0xFFFFFFFF) {
+			t.Fatalf("Value() = %#v, %v, want int64(0xFFFFFFFF)", v, err)
+		}
+	})
+	t.Run("Scan()", func(t *testing.T) {
+		for _, src := range []any{int64(42), []byte("42"), "42", "0x2a"} {
+			var f flag.Flag
+			if err := f.Scan(src); err != nil || f != 42 {
+				t.Fatalf("Scan(%#v) = %d, %v, want 42", src, uint32(f), err)
+			}
+		}
+		var f flag.Flag = 42
+		if err := f.Scan(nil); err != nil || f != 0 {
+			t.Fatalf("Scan(nil) = %d, %v, want 0", uint32(f), err)
+		}
+	})
+	t.Run("Scan() out of range", func(t *testing.T) {
+		for _, src := range []any{int64(-1), int64(0x100000000), "4294967296", 4.2} {
+			var f flag.Flag
+			if err := f.Scan(src); err == nil {
+				t.Fatalf("Scan(%#v) = %d, want an error", src, uint32(f))
+			}
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package flag
+
+import "fmt"
+
+// narrow returns `v` if it fits in `width` bits, or an error naming the flag type `to` otherwise.
+func narrow(v uint64, width int, to string) (uint64, error) {
+	if v>>width != 0 {
+		return 0, fmt.Errorf("flag: %#x does not fit in %s: bits above %d would be lost", v, to, width-1)
+	}
+	return v, nil
+}
+
+// # ToFlag16 returns the flag widened to a Flag16
+func (b Flag8) ToFlag16() Flag16 {
+	return Flag16(b)
+}
+
+// # ToFlag32 returns the flag widened to a Flag
+func (b Flag8) ToFlag32() Flag {
+	return Flag(b)
+}
+
+// # ToFlag64 returns the flag widened to a Flag64
+func (b Flag8) ToFlag64() Flag64 {
+	return Flag64(b)
+}
+
+// # ToFlag8 returns the flag narrowed to a Flag8
+//
+// returns an error if any bit above bit 7 is set.
+func (b Flag16) ToFlag8() (Flag8, error) {
+	v, err := narrow(uint64(b), 8, "Flag8")
+	return Flag8(v), err
+}
+
+// # ToFlag32 returns the flag widened to a Flag
+func (b Flag16) ToFlag32() Flag {
+	return Flag(b)
+}
+
+// # ToFlag64 returns the flag widened to a Flag64
+func (b Flag16) ToFlag64() Flag64 {
+	return Flag64(b)
+}
+
+// # ToFlag8 returns the flag narrowed to a Flag8
+//
+// returns an error if any bit above bit 7 is set.
+func (b Flag) ToFlag8() (Flag8, error) {
+	v, err := narrow(uint64(b), 8, "Flag8")
+	return Flag8(v), err
+}
+
+// # ToFlag16 returns the flag narrowed to a Flag16
+//
+// returns an error if any bit above bit 15 is set.
+func (b Flag) ToFlag16() (Flag16, error) {
+	v, err := narrow(uint64(b), 16, "Flag16")
+	return Flag16(v), err
+}
+
+// # ToFlag64 returns the flag widened to a Flag64
+func (b Flag) ToFlag64() Flag64 {
+	return Flag64(b)
+}
+
+// # ToFlag8 returns the flag narrowed to a Flag8
+//
+// returns an error if any bit above bit 7 is set.
+func (b Flag64) ToFlag8() (Flag8, error) {
+	v, err := narrow(uint64(b), 8, "Flag8")
+	return Flag8(v), err
+}
+
+// # ToFlag16 returns the flag narrowed to a Flag16
+//
+// returns an error if any bit above bit 15 is set.
+func (b Flag64) ToFlag16() (Flag16, error) {
+	v, err := narrow(uint64(b), 16, "Flag16")
+	return Flag16(v), err
+}
+
+// # ToFlag32 returns the flag narrowed to a Flag
+//
+// returns an error if any bit above bit 31 is set.
+func (b Flag64) ToFlag32() (Flag, error) {
+	v, err := narrow(uint64(b), 32, "Flag")
+	return Flag(v), err
+}
+
+// # ToFlag128 returns the flag widened to a Flag128
+func (b Flag64) ToFlag128() Flag128 {
+	return Flag128{lo: uint64(b)}
+}
+
+// # ToFlag64 returns the flag narrowed to a Flag64
+//
+// returns an error if any bit above bit 63 is set.
+func (b Flag128) ToFlag64() (Flag64, error) {
+	if b.hi != 0 {
+		return 0, fmt.Errorf("flag: Flag128 with high word %#x does not fit in Flag64: bits above 63 would be lost", b.hi)
+	}
+	return Flag64(b.lo), nil
+}
@@ -0,0 +1,52 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/chasecarlson1/go-bitflags/flag"
+)
+
+func TestWidths(t *testing.T) {
+	t.Run("widening round trip", func(t *testing.T) {
+		var f flag.Flag = 0xDEADBEEF
+		if got, err := f.ToFlag64().ToFlag32(); err != nil || got != f {
+			t.Fatalf("ToFlag64().ToFlag32() = %#x, %v, want %#x", uint32(got), err, uint32(f))
+		}
+		if got, err := f.ToFlag64().ToFlag128().ToFlag64(); err != nil || got != 0xDEADBEEF {
+			t.Fatalf("ToFlag128().ToFlag64() = %#x, %v", uint64(got), err)
+		}
+		var f8 flag.Flag8 = 0xA5
+		if got, err := f8.ToFlag16().ToFlag32().ToFlag8(); err != nil || got != f8 {
+			t.Fatalf("Flag8 round trip = %#x, %v, want %#x", uint8(got), err, uint8(f8))
+		}
+		if f8.ToFlag64() != 0xA5 || f8.ToFlag16().ToFlag64() != 0xA5 {
+			t.Fatal("Flag8 and Flag16 ToFlag64() changed the value")
+		}
+	})
+	t.Run("clean narrowing", func(t *testing.T) {
+		var f flag.Flag64 = 0xFFFF
+		if got, err := f.ToFlag16(); err != nil || got != 0xFFFF {
+			t.Fatalf("Flag64(0xFFFF).ToFlag16() = %#x, %v", uint16(got), err)
+		}
+		if got, err := flag.Flag16(0xFF).ToFlag8(); err != nil || got != 0xFF {
+			t.Fatalf("Flag16(0xFF).ToFlag8() = %#x, %v", uint8(got), err)
+		}
+	})
+	t.Run("lossy narrowing", func(t *testing.T) {
+		if _, err := flag.Flag64(1 << 32).ToFlag32(); err == nil {
+			t.Fatal("Flag64(1 << 32).ToFlag32() did not return an error")
+		}
+		if _, err := flag.Flag64(1 << 16).ToFlag16(); err == nil {
+			t.Fatal("Flag64(1 << 16).ToFlag16() did not return an error")
+		}
+		if _, err := flag.Flag(1 << 8).ToFlag8(); err == nil {
+			t.Fatal("Flag(1 << 8).ToFlag8() did not return an error")
+		}
+		if _, err := flag.Flag(1 << 31).ToFlag16(); err == nil {
+			t.Fatal("Flag(1 << 31).ToFlag16() did not return an error")
+		}
+		if _, err := flag.Bit128(64).ToFlag64(); err == nil {
+			t.Fatal("Bit128(64).ToFlag64() did not return an error")
+		}
+	})
+}
@@ -1,3 +1,3 @@
 module github.com/chasecarlson1/go-bitflags
 
-go 1.22.3
+go 1.23